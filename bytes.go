@@ -0,0 +1,180 @@
+package pretty
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BytesMode controls how byte slices and arrays are shown
+type BytesMode int
+
+const (
+	// BytesAuto shows text as a string labeled bytes, JSON expanded like JSON
+	// strings, and anything else as a hexdump
+	BytesAuto BytesMode = iota
+	// BytesHex always shows bytes as a hexdump with offsets and an ASCII
+	// gutter, like hexdump -C
+	BytesHex
+	// BytesBase64 shows bytes as a standard base64 string labeled base64
+	BytesBase64
+	// BytesList shows bytes as a list of numbers, like any other slice
+	BytesList
+)
+
+// WithBytesMode creates a new Printer with the specified bytes mode
+func (p *Printer) WithBytesMode(mode BytesMode) *Printer {
+	newP := p.copyPrinter()
+	newP.BytesMode = mode
+	return newP
+}
+
+// WithMaxBytes creates a new Printer with the specified maximum number of bytes
+func (p *Printer) WithMaxBytes(maxBytes int) *Printer {
+	newP := p.copyPrinter()
+	newP.MaxBytes = maxBytes
+	return newP
+}
+
+// isBytes reports whether val is a byte slice or array that is shown as
+// bytes rather than as a list of numbers
+func (p *Printer) isBytes(val reflect.Value) bool {
+	kind := val.Kind()
+	return p.BytesMode != BytesList &&
+		(kind == reflect.Slice || kind == reflect.Array) &&
+		val.Type().Elem().Kind() == reflect.Uint8 &&
+		val.Len() > 0
+}
+
+// bytesOf returns the bytes of a byte slice or array
+func bytesOf(val reflect.Value) []byte {
+	if val.Kind() == reflect.Slice {
+		return val.Bytes()
+	}
+	data := make([]byte, val.Len())
+	for i := range data {
+		data[i] = byte(val.Index(i).Uint())
+	}
+	return data
+}
+
+// formatBytes formats a byte slice or array as the BytesMode asks, and
+// reports whether it did
+func (p *Printer) formatBytes(s *state, val reflect.Value) bool {
+	if !p.isBytes(val) {
+		return false
+	}
+
+	data := bytesOf(val)
+	switch p.BytesMode {
+	case BytesHex:
+		p.formatHexdump(s, data)
+	case BytesBase64:
+		p.formatBase64(s, data)
+	default:
+		if js, ok := p.isJSON(string(data)); ok && p.formatJSON(s, js) {
+			break
+		}
+		if !isText(data) {
+			p.formatHexdump(s, data)
+			break
+		}
+		if p.isSecretString(string(data)) {
+			p.formatRedacted(s, val)
+			break
+		}
+		head, truncated := p.textHead(data)
+		p.formatLabeledString(s, "bytes", string(head), truncated, len(data))
+	}
+	return true
+}
+
+// formatLabeledString formats text as a string with a label, such as
+// bytes "hello", followed by the total length if the text was truncated
+func (p *Printer) formatLabeledString(s *state, label, text string, truncated bool, length int) {
+	quoted := `"` + text + `"`
+	if truncated {
+		quoted = `"` + text + `..."`
+	}
+	out := p.colorize(label, p.Styles.SpecialType) + " " + p.colorize(quoted, p.Styles.String)
+	if truncated {
+		out += " " + p.colorize(fmt.Sprintf("/* len() = %d */", length), p.Styles.Comment)
+	}
+	s.text(out)
+}
+
+// isText reports whether data is valid UTF-8 made of printable characters
+// and whitespace
+func isText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// bytesHead returns the first MaxBytes of data, and whether any were left out
+func (p *Printer) bytesHead(data []byte) ([]byte, bool) {
+	if p.MaxBytes <= 0 || len(data) <= p.MaxBytes {
+		return data, false
+	}
+	return data[:p.MaxBytes], true
+}
+
+// textHead returns the first MaxBytes of text without cutting a character in
+// two, and whether any were left out
+func (p *Printer) textHead(data []byte) ([]byte, bool) {
+	head, truncated := p.bytesHead(data)
+	for truncated && len(head) > 0 && !utf8.RuneStart(data[len(head)]) {
+		head = head[:len(head)-1]
+	}
+	return head, truncated
+}
+
+// formatBase64 formats bytes as a base64 string labeled base64
+func (p *Printer) formatBase64(s *state, data []byte) {
+	head, truncated := p.bytesHead(data)
+	p.formatLabeledString(s, "base64", base64.StdEncoding.EncodeToString(head), truncated, len(data))
+}
+
+// formatHexdump formats bytes as a hexdump -C style block, with the offset
+// of each line of 16 bytes and an ASCII gutter. Hexdumps need line breaks,
+// so bytes in inline regions, such as table cells, are shown as base64.
+func (p *Printer) formatHexdump(s *state, data []byte) {
+	if s.inline > 0 {
+		p.formatBase64(s, data)
+		return
+	}
+
+	head, truncated := p.bytesHead(data)
+
+	// Hexdumps are always laid out one line of 16 bytes per line
+	s.begin(0)
+	s.forceBreak()
+	s.text("[")
+	s.indent()
+	for _, line := range strings.Split(strings.TrimSuffix(hex.Dump(head), "\n"), "\n") {
+		// 00000000  41 42 43 44 45 46 47 48  49 4a 4b 4c 4d 4e 4f 50  |ABCDEFGHIJKLMNOP|
+		gutter := strings.Index(line, "|")
+		s.brk("", false)
+		s.text(p.colorize(line[:8], p.Styles.Comment) +
+			p.colorize(line[8:gutter], p.Styles.Number) +
+			p.colorize(line[gutter:], p.Styles.Comment))
+	}
+	if truncated {
+		s.brk("", false)
+		s.text(p.colorize(fmt.Sprintf("// len() = %d", len(data)), p.Styles.Comment))
+	}
+	s.dedent()
+	s.brk("", false)
+	s.text("]")
+	s.end()
+}
@@ -0,0 +1,124 @@
+package pretty
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	printer := New().WithColorMode(ColorNever)
+
+	tests := []struct {
+		name     string
+		printer  *Printer
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "text",
+			printer:  printer,
+			input:    []byte("hello world"),
+			expected: `bytes "hello world"`,
+		},
+		{
+			name:     "JSON",
+			printer:  printer,
+			input:    []byte(`{"a": [1, 2]}`),
+			expected: `JSON { a: [1, 2] }`,
+		},
+		{
+			name:     "raw JSON message",
+			printer:  printer,
+			input:    json.RawMessage(`[true]`),
+			expected: `JSON [true]`,
+		},
+		{
+			name:    "binary",
+			printer: printer,
+			input:   []byte("binary\x00\x01\x02\xff data!!"),
+			expected: `[
+  00000000  62 69 6e 61 72 79 00 01  02 ff 20 64 61 74 61 21  |binary.... data!|
+  00000010  21                                                |!|
+]`,
+		},
+		{
+			name:    "array",
+			printer: printer,
+			input:   [4]byte{1, 2, 3, 4},
+			expected: `[
+  00000000  01 02 03 04                                       |....|
+]`,
+		},
+		{
+			name:     "truncated text",
+			printer:  printer.WithMaxBytes(8),
+			input:    []byte("héllo wörld"),
+			expected: `bytes "héllo w..." /* len() = 13 */`,
+		},
+		{
+			name:    "truncated binary",
+			printer: printer.WithMaxBytes(16),
+			input:   make([]byte, 40),
+			expected: `[
+  00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|
+  // len() = 40
+]`,
+		},
+		{
+			name:    "inline binary",
+			printer: printer,
+			input: struct {
+				Data []byte `pretty:"inline"`
+			}{Data: []byte("binary\x00\x01\x02\xff data!!")},
+			expected: `{ Data: base64 "YmluYXJ5AAEC/yBkYXRhISE=" }`,
+		},
+		{
+			name:     "hex",
+			printer:  printer.WithBytesMode(BytesHex),
+			input:    []byte("hi"),
+			expected: "[\n  00000000  68 69                                             |hi|\n]",
+		},
+		{
+			name:     "base64",
+			printer:  printer.WithBytesMode(BytesBase64),
+			input:    []byte("hello"),
+			expected: `base64 "aGVsbG8="`,
+		},
+		{
+			name:     "truncated base64",
+			printer:  printer.WithBytesMode(BytesBase64).WithMaxBytes(3),
+			input:    []byte("hello"),
+			expected: `base64 "aGVs..." /* len() = 5 */`,
+		},
+		{
+			name:     "list",
+			printer:  printer.WithBytesMode(BytesList),
+			input:    []byte("hi"),
+			expected: `[104, 105]`,
+		},
+		{
+			name:     "empty",
+			printer:  printer,
+			input:    []byte{},
+			expected: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.printer.Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBytesDefaultTruncation(t *testing.T) {
+	result := New().WithColorMode(ColorNever).Print([]byte(strings.Repeat("a", 100)))
+	expected := `bytes "` + strings.Repeat("a", 64) + `..." /* len() = 100 */`
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
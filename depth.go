@@ -0,0 +1,74 @@
+package pretty
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// WithMaxDepth creates a new Printer with the specified maximum depth
+func (p *Printer) WithMaxDepth(depth int) *Printer {
+	newP := p.copyPrinter()
+	newP.MaxDepth = depth
+	return newP
+}
+
+// shouldCollapse reports whether val is a struct, map, slice or array with
+// something in it that is nested deeper than MaxDepth
+func (p *Printer) shouldCollapse(s *state, val reflect.Value) bool {
+	if p.MaxDepth <= 0 || s.depth < p.MaxDepth {
+		return false
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		return len(p.fieldsOf(val.Type())) > 0
+	case reflect.Map:
+		return val.Len() > 0
+	case reflect.Slice, reflect.Array:
+		return val.Len() > 0 && p.tryFormatAsUUID(val) == "" && !p.isBytes(val)
+	}
+	return false
+}
+
+// formatCollapsed summarizes a struct, map, slice or array on one line, such
+// as User{… 14 fields}, map{… 8 keys} or [… 230 items]
+func (p *Printer) formatCollapsed(val reflect.Value, includeTypeName bool) string {
+	var summary string
+	switch val.Kind() {
+	case reflect.Struct:
+		typeName := ""
+		if includeTypeName {
+			typeName = val.Type().Name()
+		}
+		summary = fmt.Sprintf("%s{… %s}", typeName, countOf(len(p.fieldsOf(val.Type())), "field"))
+	case reflect.Map:
+		summary = fmt.Sprintf("map{… %s}", countOf(val.Len(), "key"))
+	default:
+		summary = fmt.Sprintf("[… %s]", countOf(val.Len(), "item"))
+	}
+	return p.colorize(summary, p.Styles.Comment)
+}
+
+// countOf returns n with its digits grouped in thousands, followed by noun,
+// made plural unless n is 1
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return groupThousands(n) + " " + noun + "s"
+}
+
+// groupThousands formats n with commas between each group of three digits,
+// as in 49,980
+func groupThousands(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return sign + digits
+}
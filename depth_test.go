@@ -0,0 +1,80 @@
+package pretty
+
+import "testing"
+
+type depthAddress struct {
+	City, Zip string
+}
+
+type depthUser struct {
+	Name    string
+	Address depthAddress
+	Friends []*depthUser
+	Meta    map[string]any
+	Tags    []string
+}
+
+func TestMaxDepth(t *testing.T) {
+	user := &depthUser{
+		Name:    "Alice",
+		Address: depthAddress{City: "Paris"},
+		Friends: []*depthUser{{Name: "Bob"}},
+		Meta:    map[string]any{"roles": []string{"admin"}},
+	}
+	user.Friends = append(user.Friends, user)
+
+	printer := New().WithColorMode(ColorNever).WithSequentialIDs(true)
+
+	tests := []struct {
+		name     string
+		depth    int
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "top level only",
+			depth:    1,
+			input:    user,
+			expected: `depthUser{ Name: "Alice", Address: {… 2 fields}, Friends: [… 2 items], Meta: map{… 1 key}, Tags: [] }`,
+		},
+		{
+			name:  "two levels",
+			depth: 2,
+			input: user,
+			expected: `depthUser{
+  Name: "Alice",
+  Address: { City: "Paris", Zip: "" },
+  Friends: [depthUser{… 5 fields}, →#1],
+  Meta: { roles: [… 1 item] },
+  Tags: []
+}#1`,
+		},
+		{
+			name:     "scalars are never collapsed",
+			depth:    1,
+			input:    []any{1, "a", [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}},
+			expected: `[1, "a", 6ba7b810-9dad-11d1-80b4-00c04fd430c8]`,
+		},
+		{
+			name:     "map values named like their struct",
+			depth:    1,
+			input:    map[string]any{"other": depthAddress{City: "a"}, "depthAddress": depthAddress{City: "b"}},
+			expected: `{ depthAddress: {… 2 fields}, other: depthAddress{… 2 fields} }`,
+		},
+		{
+			name:     "unlimited",
+			depth:    0,
+			input:    map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}}},
+			expected: `{ a: { b: { c: 1 } } }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := printer.WithMaxDepth(tt.depth).Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,594 @@
+package pretty
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Diff returns the differences between a and b using default options, or an
+// empty string if they are equal
+func Diff(a, b any) string {
+	return Default.Diff(a, b)
+}
+
+// Diff returns the differences between a and b, or an empty string if they
+// are equal. Values are compared with the same rules they are printed with,
+// so unexported fields are ignored and JSON strings are compared by their
+// contents.
+//
+// Structs, maps and slices that differ are shown in the usual layout, with
+// runs of unchanged entries collapsed into a comment. Entries that were
+// removed or replaced are marked with - in the Error style, and entries that
+// were added or replaced are marked with + in the String style:
+//
+//	User{
+//	  - Name: "Alice",
+//	  + Name: "Bob",
+//	  … 3 unchanged fields
+//	}
+func (p *Printer) Diff(a, b any) string {
+	var sb strings.Builder
+	d := &differ{p: p, s: p.newState(&sb), inProgress: make(map[[2]uintptr]bool)}
+	for _, pattern := range p.DiffOptions.IgnorePaths {
+		d.ignore = append(d.ignore, pathPattern(pattern))
+	}
+
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if d.equal(av, bv, nil) {
+		return ""
+	}
+
+	if d.canDescend(av, bv) {
+		d.diff(av, bv, true, nil)
+	} else {
+		d.s.begin(0)
+		d.s.forceBreak()
+		d.s.text(d.marker("-"))
+		d.value(av)
+		d.s.brk("", false)
+		d.s.text(d.marker("+"))
+		d.value(bv)
+		d.s.end()
+	}
+
+	if p.hasMargin() {
+		style := lipgloss.NewStyle().Margin(p.Margin[0], p.Margin[1], p.Margin[2], p.Margin[3])
+		return style.Render(sb.String())
+	}
+	return sb.String()
+}
+
+// DiffOptions configure how Diff compares values
+type DiffOptions struct {
+	// IgnorePaths are the paths of values that are never compared. A path is
+	// made of struct field names and map keys separated by dots, and slice
+	// indices in brackets, such as "Users[0].Address.City". A * matches any
+	// single field name, map key or index, as in "Users[*].ID".
+	IgnorePaths []string
+	// FloatTolerance is the largest difference between floats that are
+	// still equal. If 0, floats must be exactly equal (default behavior)
+	FloatTolerance float64
+	// NilEqualsEmpty compares nil slices and maps as equal to empty ones
+	NilEqualsEmpty bool
+}
+
+// WithDiffOptions creates a new Printer that compares values in Diff with opts
+func (p *Printer) WithDiffOptions(opts DiffOptions) *Printer {
+	newP := p.copyPrinter()
+	newP.DiffOptions = opts
+	return newP
+}
+
+// differ holds the state of a single Diff call
+type differ struct {
+	p *Printer
+	s *state
+
+	// ignore holds the IgnorePaths as slash separated patterns for path.Match
+	ignore []string
+
+	// inProgress holds the pairs of pointers, maps and slices being compared,
+	// so that cycles are compared once
+	inProgress map[[2]uintptr]bool
+}
+
+// pathPattern converts an IgnorePaths entry into a slash separated pattern
+// for path.Match, so that * never matches across segments
+func pathPattern(p string) string {
+	p = strings.ReplaceAll(p, "[", ".")
+	p = strings.ReplaceAll(p, "]", "")
+	return strings.ReplaceAll(strings.TrimPrefix(p, "."), ".", "/")
+}
+
+// ignored reports whether the value at the path of segments is never compared
+func (d *differ) ignored(segments []string) bool {
+	if len(d.ignore) == 0 || len(segments) == 0 {
+		return false
+	}
+	joined := strings.Join(segments, "/")
+	for _, pattern := range d.ignore {
+		if ok, _ := path.Match(pattern, joined); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// child returns the path of segments extended by seg, leaving segments untouched
+func child(segments []string, seg string) []string {
+	return append(segments[:len(segments):len(segments)], seg)
+}
+
+// equal reports whether a and b at the path of segments are printed the
+// same, regardless of layout
+func (d *differ) equal(a, b reflect.Value, segments []string) bool {
+	if d.ignored(segments) {
+		return true
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	if a.Kind() == reflect.Interface {
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return d.equal(a.Elem(), b.Elem(), segments)
+	}
+
+	// Times and durations are shown rounded, so they are compared exactly
+	switch a.Type() {
+	case timeType:
+		if a.CanInterface() && b.CanInterface() {
+			return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+		}
+		return a.Equal(b)
+	case durationType:
+		return a.Int() == b.Int()
+	}
+
+	// Special types are equal when they are shown the same, except errors,
+	// whose messages and exported fields can leave out what tells them apart
+	if d.p.isSpecialHandledType(a) || d.p.isSpecialHandledType(b) {
+		if _, ok := d.p.prettyFormatterFor(a); d.p.formatterFor(a) == nil && !ok && isError(a) {
+			return deepEqual(a, b)
+		}
+		return d.sprint(a) == d.sprint(b)
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		pair := [2]uintptr{a.Pointer(), b.Pointer()}
+		if d.inProgress[pair] {
+			return true
+		}
+		d.inProgress[pair] = true
+		defer delete(d.inProgress, pair)
+		return d.equal(a.Elem(), b.Elem(), segments)
+
+	case reflect.Struct:
+		// Structs without fields shown, such as big.Int, are compared whole
+		if len(d.p.fieldsOf(a.Type())) == 0 {
+			return deepEqual(a, b)
+		}
+		for _, field := range d.p.fieldsOf(a.Type()) {
+			if !d.equal(a.Field(field.index), b.Field(field.index), child(segments, field.goName)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() {
+			return d.p.DiffOptions.NilEqualsEmpty
+		}
+		if a.Kind() == reflect.Slice {
+			if a.Pointer() == b.Pointer() {
+				return true
+			}
+			pair := [2]uintptr{a.Pointer(), b.Pointer()}
+			if d.inProgress[pair] {
+				return true
+			}
+			d.inProgress[pair] = true
+			defer delete(d.inProgress, pair)
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !d.equal(a.Index(i), b.Index(i), child(segments, d.indexSegment(i))) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.IsNil() != b.IsNil() {
+			return d.p.DiffOptions.NilEqualsEmpty
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		pair := [2]uintptr{a.Pointer(), b.Pointer()}
+		if d.inProgress[pair] {
+			return true
+		}
+		d.inProgress[pair] = true
+		defer delete(d.inProgress, pair)
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !d.equal(iter.Value(), bv, child(segments, d.keySegment(iter.Key()))) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.String:
+		if a.String() == b.String() {
+			return true
+		}
+		ja, jb, ok := d.jsonPair(a, b)
+		return ok && d.equal(ja, jb, segments)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+
+	case reflect.Float32, reflect.Float64:
+		fa, fb := a.Float(), b.Float()
+		return fa == fb || math.Abs(fa-fb) <= d.p.DiffOptions.FloatTolerance || (math.IsNaN(fa) && math.IsNaN(fb))
+
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+
+	default:
+		return d.sprint(a) == d.sprint(b)
+	}
+}
+
+// deepEqual reports whether a and b hold the same values, including the
+// ones they don't show
+func deepEqual(a, b reflect.Value) bool {
+	if a.CanInterface() && b.CanInterface() {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+	// Unexported values can only be compared when they are comparable
+	return a.Comparable() && b.Comparable() && a.Equal(b)
+}
+
+// canDescend reports whether the differences between a and b can be shown
+// within them, rather than by replacing a with b
+func (d *differ) canDescend(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return false
+		}
+		return d.canDescend(a.Elem(), b.Elem())
+	}
+
+	if d.p.isSpecialHandledType(a) || d.p.isSpecialHandledType(b) {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		return len(d.p.fieldsOf(a.Type())) > 0
+	case reflect.Map:
+		return !a.IsNil() && !b.IsNil()
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() {
+			return false
+		}
+		return d.p.tryFormatAsUUID(a) == "" && d.p.tryFormatAsUUID(b) == ""
+	case reflect.String:
+		_, _, ok := d.jsonPair(a, b)
+		return ok
+	}
+	return false
+}
+
+// diff lays out the differences within a and b at the path of segments,
+// which canDescend
+func (d *differ) diff(a, b reflect.Value, includeTypeName bool, segments []string) {
+	switch a.Kind() {
+	case reflect.Interface:
+		d.diff(a.Elem(), b.Elem(), includeTypeName, segments)
+
+	case reflect.Ptr:
+		pair := [2]uintptr{a.Pointer(), b.Pointer()}
+		d.inProgress[pair] = true
+		defer delete(d.inProgress, pair)
+		d.diff(a.Elem(), b.Elem(), includeTypeName, segments)
+
+	case reflect.Struct:
+		d.diffStruct(a, b, includeTypeName, segments)
+
+	case reflect.Map:
+		pair := [2]uintptr{a.Pointer(), b.Pointer()}
+		d.inProgress[pair] = true
+		defer delete(d.inProgress, pair)
+		d.diffMap(a, b, segments)
+
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice {
+			pair := [2]uintptr{a.Pointer(), b.Pointer()}
+			d.inProgress[pair] = true
+			defer delete(d.inProgress, pair)
+		}
+		d.diffSlice(a, b, segments)
+
+	case reflect.String:
+		ja, jb, _ := d.jsonPair(a, b)
+		d.s.text(d.p.colorize("JSON", d.p.Styles.SpecialType) + " ")
+		d.diff(ja, jb, true, segments)
+	}
+}
+
+// diffStruct lays out the differences between the fields of two structs
+func (d *differ) diffStruct(a, b reflect.Value, includeTypeName bool, segments []string) {
+	typ := a.Type()
+	typeName := ""
+	if includeTypeName {
+		typeName = typ.Name()
+	}
+
+	c := d.begin(typeName+"{", "}", true, "field")
+	for _, field := range d.p.fieldsOf(typ) {
+		fa, fb := a.Field(field.index), b.Field(field.index)
+		fieldPath := child(segments, field.goName)
+		if d.equal(fa, fb, fieldPath) {
+			c.unchanged++
+			continue
+		}
+
+		label := func() { d.s.text(d.p.fieldName(field) + ": ") }
+		format := func(v reflect.Value) {
+			omitTypeName := !d.p.isSpecialHandledType(v) && d.p.shouldOmitStructName(field.goName, v, typ.Field(field.index).Type)
+			d.p.formatFieldValue(d.s, field, v, !omitTypeName)
+		}
+
+		if d.p.isSecretField(field, fa) || d.p.isSecretField(field, fb) || !d.canDescend(fa, fb) {
+			c.replaced(label, fa, fb, format)
+			continue
+		}
+
+		c.item()
+		label()
+		omitTypeName := !d.p.isSpecialHandledType(fa) && d.p.shouldOmitStructName(field.goName, fa, typ.Field(field.index).Type)
+		d.diff(fa, fb, !omitTypeName, fieldPath)
+	}
+	c.end()
+}
+
+// diffMap lays out the differences between the entries of two maps of the
+// same type, in the order of their sorted keys
+func (d *differ) diffMap(a, b reflect.Value, segments []string) {
+	keys := a.MapKeys()
+	for _, key := range b.MapKeys() {
+		if !a.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+	d.p.sortMapKeys(d.s, keys)
+
+	c := d.begin("{", "}", true, "key")
+	for _, key := range keys {
+		va, vb := a.MapIndex(key), b.MapIndex(key)
+		keyPath := child(segments, d.keySegment(key))
+		label := func() {
+			d.p.formatMapKey(d.s, key)
+			d.s.text(": ")
+		}
+		format := func(v reflect.Value) {
+			if key.Kind() == reflect.String && d.p.isSecretName(key.String(), v) {
+				d.p.formatRedacted(d.s, v)
+				return
+			}
+			d.p.formatValue(d.s, v)
+		}
+
+		switch {
+		case d.ignored(keyPath):
+			c.unchanged++
+		case !vb.IsValid():
+			c.removed(label, va, format)
+		case !va.IsValid():
+			c.added(label, vb, format)
+		case d.equal(va, vb, keyPath):
+			c.unchanged++
+		case (key.Kind() == reflect.String && (d.p.isSecretName(key.String(), va) || d.p.isSecretName(key.String(), vb))) || !d.canDescend(va, vb):
+			c.replaced(label, va, vb, format)
+		default:
+			c.item()
+			label()
+			d.diff(va, vb, true, keyPath)
+		}
+	}
+	c.end()
+}
+
+// diffSlice lays out the differences between the elements of two slices or
+// arrays, compared by index
+func (d *differ) diffSlice(a, b reflect.Value, segments []string) {
+	c := d.begin("[", "]", false, "element")
+	noLabel := func() {}
+	format := func(v reflect.Value) { d.p.formatValue(d.s, v) }
+
+	for i := 0; i < max(a.Len(), b.Len()); i++ {
+		indexPath := child(segments, d.indexSegment(i))
+		switch {
+		case d.ignored(indexPath):
+			c.unchanged++
+		case i >= b.Len():
+			c.removed(noLabel, a.Index(i), format)
+		case i >= a.Len():
+			c.added(noLabel, b.Index(i), format)
+		case d.equal(a.Index(i), b.Index(i), indexPath):
+			c.unchanged++
+		case !d.canDescend(a.Index(i), b.Index(i)):
+			c.replaced(noLabel, a.Index(i), b.Index(i), format)
+		default:
+			c.item()
+			d.diff(a.Index(i), b.Index(i), true, indexPath)
+		}
+	}
+	c.end()
+}
+
+// diffCompound lays out the entries of a compound that differ, one per
+// line, collapsing runs of unchanged entries
+type diffCompound struct {
+	compound
+	d         *differ
+	noun      string // what the entries are called, such as "field"
+	unchanged int    // number of unchanged entries since the last one shown
+}
+
+// begin opens a diffCompound, which is always broken
+func (d *differ) begin(openBrace, closeBrace string, padBraces bool, noun string) *diffCompound {
+	c := &diffCompound{compound: d.s.beginCompound(openBrace, closeBrace, padBraces, 0), d: d, noun: noun}
+	d.s.forceBreak()
+	return c
+}
+
+// item starts the next entry that is shown, after the unchanged ones before it
+func (c *diffCompound) item() {
+	c.flushUnchanged()
+	c.compound.item()
+}
+
+// flushUnchanged shows the unchanged entries since the last entry shown
+func (c *diffCompound) flushUnchanged() {
+	if c.unchanged == 0 {
+		return
+	}
+	noun := c.noun
+	if c.unchanged != 1 {
+		noun += "s"
+	}
+	c.compound.item()
+	c.d.s.text(c.d.p.colorize(fmt.Sprintf("… %d unchanged %s", c.unchanged, noun), c.d.p.Styles.Comment))
+	c.unchanged = 0
+}
+
+// removed shows an entry that is only in a
+func (c *diffCompound) removed(label func(), v reflect.Value, format func(reflect.Value)) {
+	c.item()
+	c.d.s.text(c.d.marker("-"))
+	label()
+	c.d.format(v, format)
+}
+
+// added shows an entry that is only in b
+func (c *diffCompound) added(label func(), v reflect.Value, format func(reflect.Value)) {
+	c.item()
+	c.d.s.text(c.d.marker("+"))
+	label()
+	c.d.format(v, format)
+}
+
+// replaced shows an entry whose value in a is replaced by its value in b
+func (c *diffCompound) replaced(label func(), a, b reflect.Value, format func(reflect.Value)) {
+	c.removed(label, a, format)
+	c.added(label, b, format)
+}
+
+// end closes the compound, after the unchanged entries left
+func (c *diffCompound) end() {
+	c.flushUnchanged()
+	c.compound.end()
+}
+
+// marker returns the marker of removed (-) or added (+) entries
+func (d *differ) marker(m string) string {
+	if m == "-" {
+		return d.p.colorize("- ", d.p.Styles.Error)
+	}
+	return d.p.colorize("+ ", d.p.Styles.String)
+}
+
+// value formats a top-level value, which may be nil
+func (d *differ) value(v reflect.Value) {
+	if !v.IsValid() {
+		d.s.text(d.p.colorize("nil", d.p.Styles.Null))
+		return
+	}
+	d.format(v, func(v reflect.Value) { d.p.formatValue(d.s, v) })
+}
+
+// format formats a removed or added value with fn, showing nil slices and
+// maps as nil so that they can be told apart from empty ones
+func (d *differ) format(v reflect.Value, fn func(reflect.Value)) {
+	if u := d.p.unwrapInterface(v); (u.Kind() == reflect.Slice || u.Kind() == reflect.Map) && u.IsNil() {
+		d.s.text(d.p.colorize("nil", d.p.Styles.Null))
+		return
+	}
+	fn(v)
+}
+
+// sprint formats v on its own, for comparing values by how they are shown
+func (d *differ) sprint(v reflect.Value) string {
+	var sb strings.Builder
+	d.p.formatValue(d.p.newState(&sb), v)
+	return sb.String()
+}
+
+// indexSegment returns the path segment of a slice index
+func (d *differ) indexSegment(i int) string {
+	if len(d.ignore) == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+// keySegment returns the path segment of a map key
+func (d *differ) keySegment(key reflect.Value) string {
+	if len(d.ignore) == 0 {
+		return ""
+	}
+	return d.p.keyToString(d.s, key)
+}
+
+// jsonPair returns the parsed contents of two strings that are both JSON
+func (d *differ) jsonPair(a, b reflect.Value) (ja, jb reflect.Value, ok bool) {
+	var pa, pb any
+	if _, ok := d.p.isJSON(a.String()); !ok || json.Unmarshal([]byte(a.String()), &pa) != nil {
+		return ja, jb, false
+	}
+	if _, ok := d.p.isJSON(b.String()); !ok || json.Unmarshal([]byte(b.String()), &pb) != nil {
+		return ja, jb, false
+	}
+	return reflect.ValueOf(pa), reflect.ValueOf(pb), true
+}
@@ -0,0 +1,268 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string
+		Line int
+	}
+
+	type User struct {
+		Name    string
+		Age     int
+		Tags    []string
+		Address Address
+		Meta    map[string]any
+		Raw     string
+		Nil     []int
+	}
+
+	a := User{
+		Name:    "Alice",
+		Age:     30,
+		Tags:    []string{"a", "b", "c"},
+		Address: Address{City: "X", Zip: "1", Line: 2},
+		Meta:    map[string]any{"k": 1, "gone": true, "same": "s"},
+		Raw:     `{"a": 1, "b": [1, 2]}`,
+	}
+	b := User{
+		Name:    "Bob",
+		Age:     30,
+		Tags:    []string{"a", "x", "c", "d"},
+		Address: Address{City: "Y", Zip: "1", Line: 2},
+		Meta:    map[string]any{"k": 2, "new": "n", "same": "s"},
+		Raw:     `{"a":1,"b":[1,3]}`,
+		Nil:     []int{},
+	}
+
+	tests := []struct {
+		name     string
+		a, b     any
+		expected string
+	}{
+		{
+			name: "nested changes",
+			a:    a,
+			b:    b,
+			expected: `User{
+  - Name: "Alice",
+  + Name: "Bob",
+  … 1 unchanged field,
+  Tags: [
+    … 1 unchanged element,
+    - "b",
+    + "x",
+    … 1 unchanged element,
+    + "d"
+  ],
+  Address: {
+    - City: "X",
+    + City: "Y",
+    … 2 unchanged fields
+  },
+  Meta: {
+    - gone: true,
+    - k: 1,
+    + k: 2,
+    + new: "n",
+    … 1 unchanged key
+  },
+  Raw: JSON {
+    … 1 unchanged key,
+    b: [
+      … 1 unchanged element,
+      - 2,
+      + 3
+    ]
+  },
+  - Nil: nil,
+  + Nil: []
+}`,
+		},
+		{
+			name:     "equal",
+			a:        a,
+			b:        a,
+			expected: "",
+		},
+		{
+			name:     "JSON strings with different spacing are equal",
+			a:        `{"a": 1}`,
+			b:        `{"a":1}`,
+			expected: "",
+		},
+		{
+			name:     "different types",
+			a:        1,
+			b:        "x",
+			expected: "- 1\n+ \"x\"",
+		},
+		{
+			name:     "nil",
+			a:        nil,
+			b:        []int{1},
+			expected: "- nil\n+ [1]",
+		},
+		{
+			name:     "pointers",
+			a:        &Address{City: "X"},
+			b:        &Address{City: "Y"},
+			expected: "Address{\n  - City: \"X\",\n  + City: \"Y\",\n  … 2 unchanged fields\n}",
+		},
+	}
+
+	printer := New().WithColorMode(ColorNever)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := printer.Diff(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("Diff() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDiffCycles(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+
+	a := &Node{Value: 1}
+	a.Next = &Node{Value: 2, Next: a}
+	b := &Node{Value: 1}
+	b.Next = &Node{Value: 3, Next: b}
+
+	result := New().WithColorMode(ColorNever).Diff(a, b)
+	expected := "Node{\n  … 1 unchanged field,\n  Next: {\n    - Value: 2,\n    + Value: 3,\n    … 1 unchanged field\n  }\n}"
+	if result != expected {
+		t.Errorf("Diff() = %q, want %q", result, expected)
+	}
+
+	t.Run("slices", func(t *testing.T) {
+		a := []any{1, nil}
+		a[1] = a
+		b := []any{2, nil}
+		b[1] = b
+
+		result := New().WithColorMode(ColorNever).Diff(a, b)
+		expected := "[\n  - 1,\n  + 2,\n  … 1 unchanged element\n]"
+		if result != expected {
+			t.Errorf("Diff() = %q, want %q", result, expected)
+		}
+		if result := New().Diff(a, a); result != "" {
+			t.Errorf("Diff() = %q, want no differences", result)
+		}
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		a := map[string]any{"id": 1}
+		a["self"] = a
+		b := map[string]any{"id": 2}
+		b["self"] = b
+
+		result := New().WithColorMode(ColorNever).Diff(a, b)
+		expected := "{\n  - id: 1,\n  + id: 2,\n  … 1 unchanged key\n}"
+		if result != expected {
+			t.Errorf("Diff() = %q, want %q", result, expected)
+		}
+	})
+}
+
+func TestDiffSecrets(t *testing.T) {
+	type Login struct {
+		User     string
+		Password string
+	}
+
+	result := NewForLogs().Diff(Login{User: "a", Password: "old"}, Login{User: "a", Password: "newer"})
+	if strings.Contains(result, "old") || strings.Contains(result, "newer") {
+		t.Errorf("Diff() revealed a secret: %q", result)
+	}
+	if !strings.Contains(result, "- Password: <redacted len=3>") || !strings.Contains(result, "+ Password: <redacted len=5>") {
+		t.Errorf("Diff() = %q, want redacted changes", result)
+	}
+}
+
+func TestDiffColors(t *testing.T) {
+	printer := New().WithColorMode(ColorAlways)
+	result := printer.Diff([]int{1}, []int{2})
+	if !strings.Contains(result, printer.colorize("- ", printer.Styles.Error)) ||
+		!strings.Contains(result, printer.colorize("+ ", printer.Styles.String)) {
+		t.Errorf("Diff() = %q, want styled markers", result)
+	}
+}
+
+func TestDiffOptions(t *testing.T) {
+	type Item struct {
+		ID    int
+		Price float64
+	}
+
+	type Order struct {
+		ID      string
+		Items   []Item
+		Notes   []string
+		Headers map[string]string
+	}
+
+	a := Order{ID: "a", Items: []Item{{ID: 1, Price: 9.99}}, Headers: map[string]string{"x-request-id": "1"}}
+	b := Order{ID: "b", Items: []Item{{ID: 2, Price: 9.990001}}, Notes: []string{}, Headers: map[string]string{"x-request-id": "2"}}
+
+	tests := []struct {
+		name     string
+		opts     DiffOptions
+		expected string
+	}{
+		{
+			name: "defaults",
+			opts: DiffOptions{},
+			expected: `Order{
+  - ID: "a",
+  + ID: "b",
+  Items: [
+    Item{
+      - ID: 1,
+      + ID: 2,
+      - Price: 9.99,
+      + Price: 9.990001
+    }
+  ],
+  - Notes: nil,
+  + Notes: [],
+  Headers: {
+    - x-request-id: "1",
+    + x-request-id: "2"
+  }
+}`,
+		},
+		{
+			name: "all options",
+			opts: DiffOptions{
+				IgnorePaths:    []string{"ID", "Items[*].ID", "Headers.x-request-id"},
+				FloatTolerance: 0.001,
+				NilEqualsEmpty: true,
+			},
+			expected: "",
+		},
+		{
+			name:     "ignored indices only",
+			opts:     DiffOptions{IgnorePaths: []string{"ID", "Items[0]", "Headers.*"}, NilEqualsEmpty: true},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := New().WithColorMode(ColorNever).WithDiffOptions(tt.opts).Diff(a, b)
+			if result != tt.expected {
+				t.Errorf("Diff() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package pretty
+
+import (
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PrettyFormatter is implemented by types that describe their own pretty
+// printed representation. The returned Doc is laid out like any other value,
+// so it takes part in the single-line vs multi-line decisions of the values
+// around it, and values nested in it with Value are checked for cycles.
+//
+// PrettyFormat must not return Value of the receiver itself, as that would
+// call PrettyFormat again.
+type PrettyFormatter interface {
+	PrettyFormat(p *Printer) Doc
+}
+
+// Doc describes content for a Printer to lay out. Docs are built with Text,
+// Styled, Value, Concat, Group, Nest, Line, SoftLine, Items and Fields.
+type Doc interface {
+	layout(p *Printer, s *state)
+}
+
+type textDoc string
+
+func (d textDoc) layout(p *Printer, s *state) {
+	s.text(string(d))
+}
+
+// Text returns a Doc of literal text
+func Text(text string) Doc {
+	return textDoc(text)
+}
+
+type styledDoc struct {
+	text  string
+	style lipgloss.Style
+}
+
+func (d styledDoc) layout(p *Printer, s *state) {
+	s.text(p.colorize(d.text, d.style))
+}
+
+// Styled returns a Doc of text rendered with style when the printer uses colors
+func Styled(text string, style lipgloss.Style) Doc {
+	return styledDoc{text: text, style: style}
+}
+
+type valueDoc struct {
+	v any
+}
+
+func (d valueDoc) layout(p *Printer, s *state) {
+	if d.v == nil {
+		s.text(p.colorize("nil", p.Styles.Null))
+		return
+	}
+	p.formatValue(s, reflect.ValueOf(d.v))
+}
+
+// Value returns a Doc of v formatted by the printer, as it would be anywhere else
+func Value(v any) Doc {
+	return valueDoc{v: v}
+}
+
+type concatDoc []Doc
+
+func (d concatDoc) layout(p *Printer, s *state) {
+	for _, doc := range d {
+		doc.layout(p, s)
+	}
+}
+
+// Concat returns a Doc of docs one after the other
+func Concat(docs ...Doc) Doc {
+	return concatDoc(docs)
+}
+
+type groupDoc []Doc
+
+func (d groupDoc) layout(p *Printer, s *state) {
+	s.begin(0)
+	concatDoc(d).layout(p, s)
+	s.end()
+}
+
+// Group returns a Doc of docs laid out on a single line if they fit within
+// the printer's MaxWidth, or with every Line and SoftLine directly in the
+// group broken otherwise
+func Group(docs ...Doc) Doc {
+	return groupDoc(docs)
+}
+
+type nestDoc []Doc
+
+func (d nestDoc) layout(p *Printer, s *state) {
+	s.indent()
+	concatDoc(d).layout(p, s)
+	s.dedent()
+}
+
+// Nest returns a Doc of docs where broken lines are indented one level further
+func Nest(docs ...Doc) Doc {
+	return nestDoc(docs)
+}
+
+type lineDoc string
+
+func (d lineDoc) layout(p *Printer, s *state) {
+	s.brk(string(d), false)
+}
+
+// Line returns a Doc that is a space when its group fits on one line, and a
+// newline otherwise
+func Line() Doc {
+	return lineDoc(" ")
+}
+
+// SoftLine returns a Doc that is empty when its group fits on one line, and
+// a newline otherwise
+func SoftLine() Doc {
+	return lineDoc("")
+}
+
+type itemsDoc struct {
+	open, close string
+	items       []Doc
+}
+
+func (d itemsDoc) layout(p *Printer, s *state) {
+	c := s.beginCompound(d.open, d.close, false, 0)
+	for _, item := range d.items {
+		c.item()
+		item.layout(p, s)
+	}
+	c.end()
+}
+
+// Items returns a Doc of items laid out like the elements of a slice,
+// separated by commas between open and close
+func Items(open, close string, items ...Doc) Doc {
+	return itemsDoc{open: open, close: close, items: items}
+}
+
+// Field is a named Doc in Fields
+type Field struct {
+	Name  string
+	Value Doc
+}
+
+type fieldsDoc struct {
+	typeName string
+	fields   []Field
+}
+
+func (d fieldsDoc) layout(p *Printer, s *state) {
+	c := s.beginCompound(d.typeName+"{", "}", true, p.MaxKeysInline)
+	for _, field := range d.fields {
+		c.item()
+		s.text(field.Name + ": ")
+		field.Value.layout(p, s)
+	}
+	c.end()
+}
+
+// Fields returns a Doc of fields laid out like the fields of a struct named typeName
+func Fields(typeName string, fields ...Field) Doc {
+	return fieldsDoc{typeName: typeName, fields: fields}
+}
+
+// prettyFormatterFor returns val as a PrettyFormatter if it implements it
+func (p *Printer) prettyFormatterFor(val reflect.Value) (PrettyFormatter, bool) {
+	if !hasMethods(val) {
+		return nil, false
+	}
+
+	f, ok := val.Interface().(PrettyFormatter)
+	return f, ok
+}
@@ -0,0 +1,147 @@
+package pretty
+
+import (
+	"sort"
+	"testing"
+)
+
+// StringSet prints as a sorted set
+type StringSet map[string]struct{}
+
+func (s StringSet) PrettyFormat(p *Printer) Doc {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	items := make([]Doc, len(keys))
+	for i, k := range keys {
+		items[i] = Value(k)
+	}
+	return Items("Set{", "}", items...)
+}
+
+// Account hides its secret and shows its owner
+type Account struct {
+	Owner  *Owner
+	Secret string
+}
+
+func (a *Account) PrettyFormat(p *Printer) Doc {
+	return Fields("Account",
+		Field{Name: "Owner", Value: Value(a.Owner)},
+		Field{Name: "Secret", Value: Styled("<hidden>", p.Styles.Comment)},
+	)
+}
+
+type Owner struct {
+	Name    string
+	Account *Account
+}
+
+func TestPrettyFormatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		width    int
+		expected string
+	}{
+		{
+			name:     "single line",
+			input:    StringSet{"b": {}, "a": {}, "c": {}},
+			width:    100,
+			expected: `Set{"a", "b", "c"}`,
+		},
+		{
+			name:     "multi-line",
+			input:    StringSet{"b": {}, "a": {}, "c": {}},
+			width:    10,
+			expected: "Set{\n  \"a\",\n  \"b\",\n  \"c\"\n}",
+		},
+		{
+			name:     "empty",
+			input:    StringSet{},
+			width:    100,
+			expected: "Set{}",
+		},
+		{
+			name:     "nested in a struct",
+			input:    struct{ Tags StringSet }{Tags: StringSet{"x": {}}},
+			width:    100,
+			expected: `{ Tags: Set{"x"} }`,
+		},
+		{
+			name:     "pointer receiver",
+			input:    &Account{Owner: &Owner{Name: "Alice"}, Secret: "hunter2"},
+			width:    100,
+			expected: `Account{ Owner: Owner{ Name: "Alice", Account: nil }, Secret: <hidden> }`,
+		},
+		{
+			name:     "nil pointer",
+			input:    (*Account)(nil),
+			width:    100,
+			expected: "nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer := New().WithColorMode(ColorNever).WithMaxWidth(tt.width)
+			result := printer.Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrettyFormatterCycles(t *testing.T) {
+	account := &Account{Secret: "hunter2"}
+	account.Owner = &Owner{Name: "Alice", Account: account}
+
+	result := New().WithColorMode(ColorNever).Print(account)
+	if !hasCycle(result) {
+		t.Errorf("Expected cycle detection in result: %s", result)
+	}
+}
+
+func TestDocGroups(t *testing.T) {
+	doc := Group(Text("call("), Nest(SoftLine(), Value(1), Text(","), Line(), Value("two")), SoftLine(), Text(")"))
+
+	tests := []struct {
+		name     string
+		width    int
+		expected string
+	}{
+		{
+			name:     "fits",
+			width:    20,
+			expected: `call(1, "two")`,
+		},
+		{
+			name:     "breaks",
+			width:    10,
+			expected: "call(\n  1,\n  \"two\"\n)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer := New().WithColorMode(ColorNever).WithMaxWidth(tt.width)
+			result := printer.Print(docValue{doc})
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// docValue prints as the Doc it holds
+type docValue struct {
+	doc Doc
+}
+
+func (d docValue) PrettyFormat(p *Printer) Doc {
+	return d.doc
+}
@@ -0,0 +1,143 @@
+package pretty
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// errorFor returns val as an error along with its message if it is one.
+// Panics in the Error method, such as from nil receivers, are recovered and
+// reported as not ok.
+func (p *Printer) errorFor(val reflect.Value) (err error, msg string, ok bool) {
+	if !hasMethods(val) {
+		return nil, "", false
+	}
+
+	err, ok = val.Interface().(error)
+	if !ok {
+		return nil, "", false
+	}
+
+	defer func() {
+		if recover() != nil {
+			err, msg, ok = nil, "", false
+		}
+	}()
+
+	return err, err.Error(), true
+}
+
+// isError reports whether val is formatted as an error
+func isError(val reflect.Value) bool {
+	return hasMethods(val) && val.Type().Implements(errorType)
+}
+
+// formatError formats an error as its concrete type and message, followed by
+// its exported fields and a tree of the errors it wraps, one per line
+func (p *Printer) formatError(s *state, val reflect.Value, err error, msg string) {
+	quoted := p.colorize(fmt.Sprintf("%q", p.truncateString(msg)), p.Styles.Error)
+	if p.isSecretString(msg) {
+		quoted = p.redacted([]byte(msg), true)
+	}
+	s.text(p.colorize(val.Type().String(), p.Styles.SpecialType) + "(" + quoted + ")")
+
+	wrapped := unwrapErrors(err)
+	p.formatErrorFields(s, val, wrapped)
+
+	if len(wrapped) == 0 {
+		return
+	}
+
+	// Wrapped errors are always shown one per line, nested under their wrapper
+	s.begin(0)
+	s.forceBreak()
+	s.indent()
+	for _, child := range wrapped {
+		s.brk(" ", false)
+		s.text(p.colorize("↳ ", p.Styles.Comment))
+		p.formatValue(s, reflect.ValueOf(child))
+	}
+	s.dedent()
+	s.end()
+}
+
+// formatErrorFields formats the exported fields of a struct based error,
+// leaving out the fields that hold the wrapped errors shown in its tree
+func (p *Printer) formatErrorFields(s *state, val reflect.Value, wrapped []error) {
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return
+	}
+
+	var fields []fieldInfo
+	for _, field := range structFields(val.Type()) {
+		fieldVal := val.Field(field.index)
+		if isWrappedError(fieldVal, wrapped) || (field.omitEmpty && fieldVal.IsZero()) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	s.text(" ")
+	c := s.beginCompound("{", "}", true, p.MaxKeysInline)
+	for _, field := range fields {
+		c.item()
+		s.text(field.name + ": ")
+		p.formatFieldValue(s, field, val.Field(field.index), true)
+	}
+	c.end()
+}
+
+// unwrapErrors returns the errors wrapped by err, whether with
+// Unwrap() error, as with fmt.Errorf and %w, or with Unwrap() []error, as
+// with errors.Join
+func unwrapErrors(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		var wrapped []error
+		for _, e := range u.Unwrap() {
+			if e != nil {
+				wrapped = append(wrapped, e)
+			}
+		}
+		return wrapped
+	case interface{ Unwrap() error }:
+		if e := u.Unwrap(); e != nil {
+			return []error{e}
+		}
+	}
+	return nil
+}
+
+// isWrappedError reports whether the field holds one of the wrapped errors
+func isWrappedError(field reflect.Value, wrapped []error) bool {
+	if !field.CanInterface() {
+		return false
+	}
+
+	err, ok := field.Interface().(error)
+	if !ok || err == nil {
+		return false
+	}
+
+	for _, w := range wrapped {
+		if reflect.TypeOf(w) != reflect.TypeOf(err) {
+			continue
+		}
+		// Errors of comparable types can still hold uncomparable values, such
+		// as slices in fields of type any, which == panics on
+		if reflect.ValueOf(err).Comparable() {
+			if w == err {
+				return true
+			}
+		} else if reflect.DeepEqual(w, err) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package pretty
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+// ValidationError is a struct based error with exported fields
+type ValidationError struct {
+	Field string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError panics when asked for its message
+type PanicError struct {
+	Code int
+}
+
+func (e *PanicError) Error() string {
+	panic("no message")
+}
+
+// DetailsError is comparable by its type, but not by its value when Details
+// holds a slice
+type DetailsError struct {
+	Details any
+}
+
+func (e DetailsError) Error() string {
+	return fmt.Sprint(e.Details)
+}
+
+// OpError wraps an error it also holds in a field
+type OpError struct {
+	Op  string
+	Err error
+}
+
+func (e *OpError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+func TestErrorFormatting(t *testing.T) {
+	errBase := errors.New("boom")
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "simple error",
+			input:    errBase,
+			expected: `*errors.errorString("boom")`,
+		},
+		{
+			name:  "wrapped with %w",
+			input: fmt.Errorf("load config: %w", errBase),
+			expected: "*fmt.wrapError(\"load config: boom\")\n" +
+				"  ↳ *errors.errorString(\"boom\")",
+		},
+		{
+			name:  "chain of wrappers",
+			input: fmt.Errorf("start: %w", &fs.PathError{Op: "open", Path: "app.yaml", Err: fs.ErrNotExist}),
+			expected: "*fmt.wrapError(\"start: open app.yaml: file does not exist\")\n" +
+				"  ↳ *fs.PathError(\"open app.yaml: file does not exist\") { Op: \"open\", Path: \"app.yaml\" }\n" +
+				"    ↳ *errors.errorString(\"file does not exist\")",
+		},
+		{
+			name:  "joined errors",
+			input: errors.Join(errors.New("first"), errors.New("second")),
+			expected: "*errors.joinError(\"first\\nsecond\")\n" +
+				"  ↳ *errors.errorString(\"first\")\n" +
+				"  ↳ *errors.errorString(\"second\")",
+		},
+		{
+			name:  "struct based error",
+			input: &ValidationError{Field: "email", Err: errBase},
+			expected: "*pretty.ValidationError(\"email: boom\") { Field: \"email\" }\n" +
+				"  ↳ *errors.errorString(\"boom\")",
+		},
+		{
+			name:     "nil error",
+			input:    (*ValidationError)(nil),
+			expected: "nil",
+		},
+		{
+			name:     "panicking Error method",
+			input:    &PanicError{Code: 3},
+			expected: "PanicError{ Code: 3 }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := New().WithColorMode(ColorNever).Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorFields(t *testing.T) {
+	type Result struct {
+		ID  int
+		Err error
+	}
+
+	t.Run("single error stays inline", func(t *testing.T) {
+		result := New().WithColorMode(ColorNever).Print(Result{ID: 1, Err: errors.New("boom")})
+		expected := `Result{ ID: 1, Err: *errors.errorString("boom") }`
+		if result != expected {
+			t.Errorf("Print() = %q, want %q", result, expected)
+		}
+	})
+
+	t.Run("chain breaks the struct", func(t *testing.T) {
+		result := New().WithColorMode(ColorNever).Print(Result{ID: 1, Err: fmt.Errorf("fetch: %w", errors.New("timeout"))})
+		expected := "Result{\n" +
+			"  ID: 1,\n" +
+			"  Err: *fmt.wrapError(\"fetch: timeout\")\n" +
+			"    ↳ *errors.errorString(\"timeout\")\n" +
+			"}"
+		if result != expected {
+			t.Errorf("Print() = %q, want %q", result, expected)
+		}
+	})
+
+	t.Run("uncomparable wrapped error", func(t *testing.T) {
+		result := New().WithColorMode(ColorNever).Print(&OpError{Op: "load", Err: DetailsError{Details: []string{"a"}}})
+		expected := "*pretty.OpError(\"load: [a]\") { Op: \"load\" }\n" +
+			"  ↳ pretty.DetailsError(\"[a]\") { Details: [\"a\"] }"
+		if result != expected {
+			t.Errorf("Print() = %q, want %q", result, expected)
+		}
+	})
+
+	t.Run("nil error field", func(t *testing.T) {
+		result := New().WithColorMode(ColorNever).Print(Result{ID: 1})
+		expected := "Result{ ID: 1, Err: nil }"
+		if result != expected {
+			t.Errorf("Print() = %q, want %q", result, expected)
+		}
+	})
+}
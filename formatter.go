@@ -0,0 +1,53 @@
+package pretty
+
+import (
+	"maps"
+	"reflect"
+)
+
+// RegisterFormatter registers a function that formats values of type typ,
+// taking precedence over the built-in formatting. The function receives the
+// value itself and its result is printed as is.
+//
+// Printers derived from p afterwards inherit the formatter, while the
+// printer p was derived from and printers already derived from p are not
+// affected. Formatters should be registered before p is used concurrently.
+func (p *Printer) RegisterFormatter(typ reflect.Type, fn func(v any) string) {
+	// Copy on write, as derived printers share the map with their parent
+	formatters := make(map[reflect.Type]func(any) string, len(p.formatters)+1)
+	maps.Copy(formatters, p.formatters)
+	formatters[typ] = fn
+	p.formatters = formatters
+}
+
+// RegisterFormatterFor registers a function that formats values of type T
+// on p. See Printer.RegisterFormatter.
+func RegisterFormatterFor[T any](p *Printer, fn func(v T) string) {
+	p.RegisterFormatter(reflect.TypeFor[T](), func(v any) string {
+		return fn(v.(T))
+	})
+}
+
+// formatterFor returns the custom formatter registered for the type of val, if any
+func (p *Printer) formatterFor(val reflect.Value) func(any) string {
+	if len(p.formatters) == 0 || !hasMethods(val) {
+		return nil
+	}
+	return p.formatters[val.Type()]
+}
+
+// hasMethods reports whether val is formatted by its type's methods or
+// formatters. Interfaces are handled by their underlying value, and nil
+// pointers are printed as nil like any other.
+func hasMethods(val reflect.Value) bool {
+	if !val.CanInterface() {
+		return false
+	}
+	switch val.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Ptr:
+		return !val.IsNil()
+	}
+	return true
+}
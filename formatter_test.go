@@ -0,0 +1,112 @@
+package pretty
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+type GeoPoint struct {
+	Lat, Lng float64
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	printer := New().WithColorMode(ColorNever)
+	printer.RegisterFormatter(reflect.TypeOf(Money{}), func(v any) string {
+		m := v.(Money)
+		return fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency)
+	})
+
+	type Order struct {
+		ID    int
+		Total Money
+		Tip   *Money
+	}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "value",
+			input:    Money{Cents: 1999, Currency: "USD"},
+			expected: "19.99 USD",
+		},
+		{
+			name:     "pointer",
+			input:    &Money{Cents: 500, Currency: "EUR"},
+			expected: "5.00 EUR",
+		},
+		{
+			name:     "struct fields",
+			input:    Order{ID: 1, Total: Money{Cents: 1999, Currency: "USD"}},
+			expected: "Order{ ID: 1, Total: 19.99 USD, Tip: nil }",
+		},
+		{
+			name:     "map key matching type name",
+			input:    map[string]interface{}{"Money": Money{Cents: 100, Currency: "USD"}},
+			expected: "{ Money: 1.00 USD }",
+		},
+		{
+			name:     "other types are unaffected",
+			input:    GeoPoint{Lat: 1.5, Lng: 2.5},
+			expected: "GeoPoint{ Lat: 1.5, Lng: 2.5 }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := printer.Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegisterFormatterFor(t *testing.T) {
+	printer := New().WithColorMode(ColorNever)
+	RegisterFormatterFor(printer, func(p GeoPoint) string {
+		return fmt.Sprintf("(%g, %g)", p.Lat, p.Lng)
+	})
+
+	result := printer.Print([]GeoPoint{{Lat: 1, Lng: 2}, {Lat: 3, Lng: 4}})
+	expected := "[(1, 2), (3, 4)]"
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
+func TestRegisterFormatterCopyOnWrite(t *testing.T) {
+	point := GeoPoint{Lat: 1, Lng: 2}
+
+	parent := New().WithColorMode(ColorNever)
+	RegisterFormatterFor(parent, func(p GeoPoint) string { return "parent" })
+
+	// Derived printers inherit formatters registered before they were derived
+	child := parent.WithMaxWidth(50)
+	if result := child.Print(point); result != "parent" {
+		t.Errorf("child.Print() = %q, want %q", result, "parent")
+	}
+
+	// Registering on the child doesn't affect the parent
+	RegisterFormatterFor(child, func(p GeoPoint) string { return "child" })
+	if result := child.Print(point); result != "child" {
+		t.Errorf("child.Print() = %q, want %q", result, "child")
+	}
+	if result := parent.Print(point); result != "parent" {
+		t.Errorf("parent.Print() = %q, want %q", result, "parent")
+	}
+
+	// Nor the other way around
+	RegisterFormatterFor(parent, func(m Money) string { return "money" })
+	if result := child.Print(Money{}); result == "money" {
+		t.Errorf("child.Print() = %q, expected the parent's formatter to not apply", result)
+	}
+}
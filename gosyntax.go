@@ -0,0 +1,460 @@
+package pretty
+
+import (
+	"fmt"
+	"go/format"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WithGoSyntax creates a new Printer that formats values as Go literals, see
+// Printer.GoSyntax
+func (p *Printer) WithGoSyntax(enabled bool) *Printer {
+	newP := p.copyPrinter()
+	newP.GoSyntax = enabled
+	return newP
+}
+
+// goSourcePrefix makes a Go expression a source file that gofmt formats
+const goSourcePrefix = "package p\n\nvar x = "
+
+// formatGoSource lays out v as a Go expression, and formats it with gofmt to
+// align it the way gofmt does
+func (p *Printer) formatGoSource(out writer, v any) {
+	var sb strings.Builder
+	p.formatGo(p.newState(&sb), reflect.ValueOf(v), nil, false)
+
+	src, err := format.Source([]byte(goSourcePrefix + sb.String() + "\n"))
+	if err != nil {
+		// Leave expressions gofmt can't parse as they are
+		out.WriteString(sb.String())
+		return
+	}
+	out.WriteString(strings.TrimSuffix(strings.TrimPrefix(string(src), goSourcePrefix), "\n"))
+}
+
+// formatGo formats val as a Go expression for a position of the static type
+// typ, such as the type of a struct field. A nil typ is a position of any
+// type. With elide, composite literals leave out their type as it is implied
+// by the enclosing literal.
+func (p *Printer) formatGo(s *state, val reflect.Value, typ reflect.Type, elide bool) {
+	if !val.IsValid() {
+		s.text("nil")
+		return
+	}
+
+	// Interfaces are formatted by their underlying value, which then needs its
+	// own type
+	if val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			p.formatGoNil(s, val, typ)
+			return
+		}
+		p.formatGo(s, val.Elem(), val.Type(), false)
+		return
+	}
+	inInterface := typ == nil || typ.Kind() == reflect.Interface
+	elide = elide && !inInterface && typ == val.Type()
+
+	switch val.Type() {
+	case timeType:
+		p.formatGoTime(s, val.Interface().(time.Time))
+		return
+	case durationType:
+		p.formatGoDuration(s, time.Duration(val.Int()), inInterface)
+		return
+	}
+
+	// errors.New is only assignable to interfaces, so errors of concrete
+	// types are written like any other value of their type
+	if err, msg, ok := p.errorFor(val); ok && inInterface {
+		lit := strconv.Quote(msg)
+		if p.isSecretString(msg) {
+			lit = fmt.Sprintf(`"" /* redacted len=%d */`, len(msg))
+		}
+		s.text(fmt.Sprintf("errors.New(%s) /* %T */", lit, err))
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			p.formatGoNil(s, val, typ)
+			return
+		}
+
+		if !p.enterGo(s, val) {
+			return
+		}
+		defer delete(s.visited, val.Pointer())
+
+		elem := val.Elem()
+		switch elem.Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+			if elem.Type() != timeType {
+				// &T{} is left out entirely where T is implied
+				if !elide {
+					s.text("&")
+				}
+				p.formatGo(s, elem, elem.Type(), elide)
+				return
+			}
+		}
+
+		// Other values have no literal to take the address of, so they are
+		// put in a slice literal of their own
+		s.text(fmt.Sprintf("&[]%s{", goType(elem.Type())))
+		p.formatGo(s, elem, elem.Type(), true)
+		s.text("}[0]")
+
+	case reflect.Struct:
+		p.formatGoStruct(s, val, elide)
+
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			p.formatGoNil(s, val, typ)
+			return
+		}
+		if val.Kind() == reflect.Slice && !p.enterGo(s, val) {
+			return
+		}
+		if val.Kind() == reflect.Slice {
+			defer delete(s.visited, val.Pointer())
+		}
+		if val.Type().Elem().Kind() == reflect.Uint8 && val.Kind() == reflect.Slice && utf8.Valid(val.Bytes()) {
+			s.text(fmt.Sprintf("%s(%s)", goType(val.Type()), strconv.Quote(string(val.Bytes()))))
+			return
+		}
+		p.formatGoSlice(s, val, elide)
+
+	case reflect.Map:
+		if val.IsNil() {
+			p.formatGoNil(s, val, typ)
+			return
+		}
+		if !p.enterGo(s, val) {
+			return
+		}
+		defer delete(s.visited, val.Pointer())
+		p.formatGoMap(s, val, elide)
+
+	case reflect.String:
+		str := val.String()
+		lit := strconv.Quote(str)
+		if p.isSecretString(str) {
+			lit = fmt.Sprintf(`"" /* redacted len=%d */`, len(str))
+		}
+		p.formatGoScalar(s, val, lit, inInterface)
+
+	case reflect.Bool:
+		p.formatGoScalar(s, val, strconv.FormatBool(val.Bool()), inInterface)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.formatGoScalar(s, val, strconv.FormatInt(val.Int(), 10), inInterface)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.formatGoScalar(s, val, strconv.FormatUint(val.Uint(), 10), inInterface)
+
+	case reflect.Float32, reflect.Float64:
+		p.formatGoScalar(s, val, goFloat(val.Float(), val.Type().Bits()), inInterface)
+
+	case reflect.Complex64, reflect.Complex128:
+		c := val.Complex()
+		lit := fmt.Sprintf("complex(%s, %s)", goFloat(real(c), 64), goFloat(imag(c), 64))
+		p.formatGoScalar(s, val, lit, inInterface && val.Type() != reflect.TypeOf(complex128(0)))
+
+	default:
+		// Channels, functions and unsafe pointers can't be written as literals
+		s.text(fmt.Sprintf("nil /* %s */", goType(val.Type())))
+	}
+}
+
+// enterGo marks the pointer, slice or map val as being formatted. If it
+// already is, val is part of a cycle that can't be written as a literal, and
+// nil is formatted in its place instead.
+func (p *Printer) enterGo(s *state, val reflect.Value) bool {
+	ptr := val.Pointer()
+	if s.visited[ptr] {
+		s.text(fmt.Sprintf("nil /* cycle to %s */", goType(val.Type())))
+		return false
+	}
+	s.visited[ptr] = true
+	return true
+}
+
+// formatGoScalar formats the literal lit of a basic value, converting it to
+// its type when the position doesn't imply it
+func (p *Printer) formatGoScalar(s *state, val reflect.Value, lit string, inInterface bool) {
+	if inInterface && !isDefaultType(val.Type()) {
+		lit = fmt.Sprintf("%s(%s)", goType(val.Type()), lit)
+	}
+	s.text(lit)
+}
+
+// goType returns the name of typ as it is written in gofmt formatted source
+func goType(typ reflect.Type) string {
+	name := typ.String()
+	name = strings.ReplaceAll(name, "interface {}", "any")
+	name = strings.ReplaceAll(name, "struct {", "struct{")
+	return name
+}
+
+// isDefaultType reports whether typ is the type an untyped constant of its
+// kind defaults to, so that its literals need no conversion
+func isDefaultType(typ reflect.Type) bool {
+	switch typ {
+	case reflect.TypeOf(""), reflect.TypeOf(false), reflect.TypeOf(0), reflect.TypeOf(0.0), reflect.TypeOf(complex128(0)):
+		return true
+	}
+	return false
+}
+
+// goFloat formats f as a Go floating-point literal or expression
+func goFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+
+	lit := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(lit, ".e") {
+		lit += ".0"
+	}
+	return lit
+}
+
+// formatGoNil formats a nil value, converting it to its type when the
+// position doesn't imply it
+func (p *Printer) formatGoNil(s *state, val reflect.Value, typ reflect.Type) {
+	lit := "nil"
+	if (typ == nil || typ.Kind() == reflect.Interface) && val.Kind() != reflect.Interface {
+		if val.Kind() == reflect.Ptr {
+			lit = fmt.Sprintf("(%s)(%s)", goType(val.Type()), lit)
+		} else {
+			lit = fmt.Sprintf("%s(%s)", goType(val.Type()), lit)
+		}
+	}
+	s.text(lit)
+}
+
+// formatGoTime formats t as a call to time.Date
+func (p *Printer) formatGoTime(s *state, t time.Time) {
+	if t.IsZero() {
+		s.text("time.Time{}")
+		return
+	}
+
+	var loc string
+	switch t.Location() {
+	case time.UTC:
+		loc = "time.UTC"
+	case time.Local:
+		loc = "time.Local"
+	default:
+		name, offset := t.Zone()
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+
+	s.text(fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc))
+}
+
+// formatGoDuration formats d as a sum of multiples of the time units
+func (p *Printer) formatGoDuration(s *state, d time.Duration, inInterface bool) {
+	if d == 0 {
+		lit := "0"
+		if inInterface {
+			lit = "time.Duration(0)"
+		}
+		s.text(lit)
+		return
+	}
+
+	units := []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+		{time.Nanosecond, "time.Nanosecond"},
+	}
+
+	// The smallest unit is negated instead of d, as -d overflows for the
+	// smallest duration
+	neg := d < 0
+	var terms []string
+	for _, unit := range units {
+		n := d / unit.d
+		d -= n * unit.d
+		if n == 0 {
+			continue
+		}
+		if n < 0 {
+			n = -n
+		}
+		terms = append(terms, fmt.Sprintf("%d*%s", n, unit.name))
+	}
+
+	var lit string
+	switch {
+	case len(terms) == 1:
+		lit = strings.Replace(terms[0], "*", " * ", 1)
+		if neg {
+			lit = "-" + lit
+		}
+	case neg:
+		lit = "-(" + strings.Join(terms, " + ") + ")"
+	default:
+		lit = strings.Join(terms, " + ")
+	}
+	s.text(lit)
+}
+
+// formatGoStruct formats a struct as a composite literal of its exported
+// fields that are not zero
+func (p *Printer) formatGoStruct(s *state, val reflect.Value, elide bool) {
+	typ := val.Type()
+	c := p.beginGoCompound(s, typ, elide)
+
+	var unexported []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+		if fieldVal.IsZero() {
+			continue
+		}
+		if !field.IsExported() {
+			unexported = append(unexported, field.Name)
+			continue
+		}
+
+		info, _ := parseFieldTag(field.Tag.Get("pretty"))
+		info.goName, info.name = field.Name, field.Name
+		if p.isSecretField(info, fieldVal) {
+			c.comment(fmt.Sprintf("%s is redacted", field.Name))
+			continue
+		}
+
+		c.item()
+		s.text(field.Name + ": ")
+		p.formatGo(s, fieldVal, field.Type, false)
+	}
+
+	if len(unexported) > 0 {
+		c.comment(fmt.Sprintf("unexported fields can't be set: %s", strings.Join(unexported, ", ")))
+	}
+	c.end()
+}
+
+// formatGoSlice formats a slice or array as a composite literal
+func (p *Printer) formatGoSlice(s *state, val reflect.Value, elide bool) {
+	elemType := val.Type().Elem()
+	c := p.beginGoCompound(s, val.Type(), elide)
+
+	length := val.Len()
+	shown := length
+	if p.MaxSliceLength > 0 && length > p.MaxSliceLength {
+		shown = p.MaxSliceLength
+	}
+	for i := 0; i < shown; i++ {
+		c.item()
+		p.formatGo(s, val.Index(i), elemType, true)
+	}
+	if shown < length {
+		c.comment(fmt.Sprintf("%d more elements not shown", length-shown))
+	}
+	c.end()
+}
+
+// formatGoMap formats a map as a composite literal, in the order of its
+// sorted keys
+func (p *Printer) formatGoMap(s *state, val reflect.Value, elide bool) {
+	typ := val.Type()
+	keys := val.MapKeys()
+	p.sortMapKeys(s, keys)
+
+	c := p.beginGoCompound(s, typ, elide)
+	for _, key := range keys {
+		if key.Kind() == reflect.String && p.isSecretName(key.String(), val.MapIndex(key)) {
+			c.comment(fmt.Sprintf("%q is redacted", key.String()))
+			continue
+		}
+
+		c.item()
+		p.formatGo(s, key, typ.Key(), true)
+		s.text(": ")
+		p.formatGo(s, val.MapIndex(key), typ.Elem(), true)
+	}
+	c.end()
+}
+
+// goCompound lays out the elements of a Go composite literal, which has a
+// trailing comma when it spans multiple lines
+type goCompound struct {
+	s           *state
+	items       int
+	lastComment bool // whether the last item is a comment, which takes no comma
+}
+
+// beginGoCompound opens the composite literal of a value of type typ
+func (p *Printer) beginGoCompound(s *state, typ reflect.Type, elide bool) *goCompound {
+	s.begin(p.MaxKeysInline)
+	if !elide {
+		s.text(goType(typ))
+	}
+	s.text("{")
+	return &goCompound{s: s}
+}
+
+// item starts the next element
+func (c *goCompound) item() {
+	c.separate()
+	c.lastComment = false
+	c.s.item()
+}
+
+// comment adds a line comment, which always breaks the literal
+func (c *goCompound) comment(text string) {
+	c.s.forceBreak()
+	c.separate()
+	c.lastComment = true
+	c.s.text("// " + text)
+}
+
+// separate starts a new line in the literal, after the comma of the last item
+func (c *goCompound) separate() {
+	if c.items == 0 {
+		c.s.indent()
+		c.s.brk("", false)
+	} else {
+		if !c.lastComment {
+			c.s.text(",")
+		}
+		c.s.brk(" ", false)
+	}
+	c.items++
+}
+
+// end closes the composite literal
+func (c *goCompound) end() {
+	if c.items > 0 {
+		if !c.lastComment {
+			c.s.brokenText(",")
+		}
+		c.s.dedent()
+		c.s.brk("", true)
+	}
+	c.s.text("}")
+	c.s.end()
+}
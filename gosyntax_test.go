@@ -0,0 +1,131 @@
+package pretty
+
+import (
+	"errors"
+	"go/format"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+type goAddress struct {
+	City string
+	Zip  *int
+}
+
+type goUser struct {
+	Name     string
+	Age      int
+	Score    float64
+	Tags     []string
+	Address  *goAddress
+	Previous []*goAddress
+	Meta     map[string]any
+	Created  time.Time
+	Timeout  time.Duration
+	Err      error
+	Updates  chan int
+	Next     *goUser
+	Empty    []int
+	Password string `pretty:"secret"`
+	internal string
+}
+
+func TestGoSyntax(t *testing.T) {
+	zip := 94107
+	user := &goUser{
+		Name:     "Alice",
+		Age:      30,
+		Score:    2,
+		Tags:     []string{"admin"},
+		Address:  &goAddress{City: "San Francisco", Zip: &zip},
+		Previous: []*goAddress{{City: "Oakland"}},
+		Meta:     map[string]any{"n": 1, "f": 1.5, "small": int8(2), "list": []int{1}, "none": (*int)(nil)},
+		Created:  time.Date(2026, 10, 16, 13, 4, 5, 6, time.UTC),
+		Timeout:  90*time.Minute + time.Millisecond,
+		Err:      errors.New("boom"),
+		Updates:  make(chan int),
+		Empty:    []int{},
+		Password: "hunter2",
+		internal: "x",
+	}
+	user.Next = user
+
+	tests := []struct {
+		name     string
+		input    any
+		width    int
+		expected string
+	}{
+		{
+			name:  "struct",
+			input: user,
+			width: 100,
+			expected: "&pretty.goUser{\n" +
+				"\tName:     \"Alice\",\n" +
+				"\tAge:      30,\n" +
+				"\tScore:    2.0,\n" +
+				"\tTags:     []string{\"admin\"},\n" +
+				"\tAddress:  &pretty.goAddress{City: \"San Francisco\", Zip: &[]int{94107}[0]},\n" +
+				"\tPrevious: []*pretty.goAddress{{City: \"Oakland\"}},\n" +
+				"\tMeta:     map[string]any{\"f\": 1.5, \"list\": []int{1}, \"n\": 1, \"none\": (*int)(nil), \"small\": int8(2)},\n" +
+				"\tCreated:  time.Date(2026, time.October, 16, 13, 4, 5, 6, time.UTC),\n" +
+				"\tTimeout:  1*time.Hour + 30*time.Minute + 1*time.Millisecond,\n" +
+				"\tErr:      errors.New(\"boom\"), /* *errors.errorString */\n" +
+				"\tUpdates:  nil,                /* chan int */\n" +
+				"\tNext:     nil,                /* cycle to *pretty.goUser */\n" +
+				"\tEmpty:    []int{},\n" +
+				"\t// Password is redacted\n" +
+				"\t// unexported fields can't be set: internal\n" +
+				"}",
+		},
+		{
+			name:     "elided element types",
+			input:    []goAddress{{City: "a"}, {City: "b"}},
+			width:    100,
+			expected: `[]pretty.goAddress{{City: "a"}, {City: "b"}}`,
+		},
+		{
+			name:     "broken literals have trailing commas",
+			input:    map[string][]int{"a": {1, 2, 3}, "b": nil},
+			width:    20,
+			expected: "map[string][]int{\n\t\"a\": {1, 2, 3},\n\t\"b\": nil,\n}",
+		},
+		{
+			name:     "values in interfaces keep their types",
+			input:    []any{1, 2.5, uint8(3), "s", nil, time.Second, -2 * time.Minute, []byte("hi"), struct{ A int }{1}},
+			width:    200,
+			expected: `[]any{1, 2.5, uint8(3), "s", nil, 1 * time.Second, -2 * time.Minute, []uint8("hi"), struct{ A int }{A: 1}}`,
+		},
+		{
+			name:     "errors of concrete types",
+			input:    struct{ Err *fs.PathError }{&fs.PathError{Op: "open", Path: "/x", Err: errors.New("nope")}},
+			width:    200,
+			expected: `struct{ Err *fs.PathError }{Err: &fs.PathError{Op: "open", Path: "/x", Err: errors.New("nope") /* *errors.errorString */}}`,
+		},
+		{
+			name:     "times in other zones",
+			input:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("PDT", -7*60*60)),
+			width:    100,
+			expected: `time.Date(2026, time.January, 2, 3, 4, 5, 0, time.FixedZone("PDT", -25200))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := New().WithColorMode(ColorNever).WithGoSyntax(true).WithMaxWidth(tt.width).Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+
+			src := "package p\n\nvar x = " + result + "\n"
+			formatted, err := format.Source([]byte(src))
+			if err != nil {
+				t.Fatalf("Print() is not valid Go: %v\n%s", err, result)
+			}
+			if string(formatted) != src {
+				t.Errorf("Print() is not gofmt formatted:\n%s\nwant:\n%s", result, formatted)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package pretty
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// writer is the output a layout renders to
+type writer interface {
+	io.StringWriter
+	io.ByteWriter
+}
+
+// tokenKind identifies the kind of a layout token
+type tokenKind int
+
+const (
+	tokText       tokenKind = iota // literal text
+	tokBreak                       // a line break, or its flat text when the group fits
+	tokBegin                       // opens a group
+	tokEnd                         // closes the innermost group
+	tokIndent                      // increases the nesting level of following breaks
+	tokDedent                      // decreases the nesting level of following breaks
+	tokBrokenText                  // literal text that is only written when its group is broken
+)
+
+// token is a single instruction in the layout stream
+type token struct {
+	kind tokenKind
+	text string // text for tokText, flat text for tokBreak
+	g    *group // group opened by tokBegin
+}
+
+// group is a run of tokens that is laid out either entirely on one line or
+// with each of its own breaks turned into a newline
+type group struct {
+	start    int  // flat width of the stream when the group was opened
+	slack    int  // flat width of the group's own free breaks
+	items    int  // number of items added to the group
+	maxItems int  // break once more than this many items are added (0 = no limit)
+	broken   bool // whether the group has been decided to break
+	fixed    bool // whether the group is always flat, as it is in an inline region
+}
+
+// width returns the flat width of the group when the stream is total wide
+func (g *group) width(total int) int {
+	return total - g.start - g.slack
+}
+
+// layout is a streaming line-breaking engine in the style of Oppen's pretty
+// printer. Formatters describe their output once as a stream of text,
+// breaks, nesting changes and groups, and the layout decides for each group
+// whether it fits on a single line.
+//
+// A group fits when its own flat width is at most maxWidth, regardless of
+// the column it starts at, and it holds no more than its maximum number of
+// items. A group is therefore decided as soon as it either closes or
+// overflows, so the layout only ever holds back about one line of output
+// and every value is formatted exactly once.
+type layout struct {
+	out      writer
+	maxWidth int
+
+	width   int      // flat width of every token pushed so far
+	open    []*group // groups that have been opened but not closed, innermost last
+	pending []*group // open groups that are not decided yet, outermost first
+	buf     []token  // tokens held back until the outermost pending group is decided
+
+	inline    int  // depth of inline regions, whose groups never break
+	breakNext bool // whether the next group opened breaks regardless of its width
+
+	// Output state
+	level int    // nesting level applied to broken lines
+	modes []bool // whether each group being written is flat, innermost last
+}
+
+// newLayout creates a layout that writes to out, breaking groups wider than maxWidth
+func newLayout(out writer, maxWidth int) *layout {
+	return &layout{out: out, maxWidth: maxWidth}
+}
+
+// text appends literal text
+func (l *layout) text(s string) {
+	if s == "" {
+		return
+	}
+	l.push(token{kind: tokText, text: s}, lipgloss.Width(s))
+}
+
+// brk appends a break that is rendered as flat when its group fits, and as a
+// newline at the current nesting level otherwise. A free break does not
+// count towards the width of its own group, but still does for the groups
+// enclosing it.
+func (l *layout) brk(flat string, free bool) {
+	if free && len(l.open) > 0 {
+		l.open[len(l.open)-1].slack += len(flat)
+	}
+	l.push(token{kind: tokBreak, text: flat}, len(flat))
+}
+
+// brokenText appends literal text that is only written when the innermost
+// group is broken, such as the trailing comma of a multi-line Go literal
+func (l *layout) brokenText(s string) {
+	l.push(token{kind: tokBrokenText, text: s}, 0)
+}
+
+// indent increases the nesting level of the breaks that follow
+func (l *layout) indent() {
+	l.push(token{kind: tokIndent}, 0)
+}
+
+// dedent decreases the nesting level of the breaks that follow
+func (l *layout) dedent() {
+	l.push(token{kind: tokDedent}, 0)
+}
+
+// begin opens a new group that breaks once it holds more than maxItems items.
+// If maxItems is 0, only the width of the group is considered.
+func (l *layout) begin(maxItems int) {
+	breakNext := l.breakNext
+	l.breakNext = false
+
+	g := &group{start: l.width, maxItems: maxItems}
+	l.open = append(l.open, g)
+	if l.inline > 0 {
+		g.fixed = true
+		l.push(token{kind: tokBegin, g: g}, 0)
+		return
+	}
+
+	l.pending = append(l.pending, g)
+	l.buf = append(l.buf, token{kind: tokBegin, g: g})
+	if breakNext {
+		l.breakGroup(g)
+	}
+}
+
+// end closes the innermost group. A group that has not broken by the time it
+// is closed fits, and is laid out on a single line.
+func (l *layout) end() {
+	g := l.open[len(l.open)-1]
+	l.open = l.open[:len(l.open)-1]
+
+	l.push(token{kind: tokEnd}, 0)
+	if g.broken || g.fixed {
+		return
+	}
+
+	// Undecided groups are always the innermost ones, so this is the last
+	l.pending = l.pending[:len(l.pending)-1]
+	if len(l.pending) == 0 {
+		l.flush(nil)
+	}
+}
+
+// item records that an item was added to the innermost group
+func (l *layout) item() {
+	g := l.open[len(l.open)-1]
+	g.items++
+	if g.maxItems > 0 && g.items > g.maxItems {
+		l.breakGroup(g)
+	}
+}
+
+// forceBreak breaks the innermost group regardless of its width
+func (l *layout) forceBreak() {
+	l.breakGroup(l.open[len(l.open)-1])
+}
+
+// breakGroup breaks g along with every pending group enclosing it, since an
+// enclosing group can't fit on one line when g doesn't. Groups in an inline
+// region never break.
+func (l *layout) breakGroup(g *group) {
+	if g.fixed {
+		return
+	}
+	for !g.broken {
+		l.breakOutermost()
+	}
+}
+
+// beginInline starts a region whose groups are all laid out on a single
+// line. It still counts towards the width of the groups enclosing it.
+func (l *layout) beginInline() {
+	l.inline++
+}
+
+// endInline ends the innermost inline region
+func (l *layout) endInline() {
+	l.inline--
+}
+
+// breakNextGroup makes the next group opened break regardless of its width
+func (l *layout) breakNextGroup() {
+	l.breakNext = true
+}
+
+// clearBreakNext cancels breakNextGroup if no group was opened since
+func (l *layout) clearBreakNext() {
+	l.breakNext = false
+}
+
+// push appends a token of the given flat width to the stream
+func (l *layout) push(t token, width int) {
+	l.width += width
+	if len(l.pending) == 0 {
+		l.write(t)
+		return
+	}
+
+	l.buf = append(l.buf, t)
+
+	// The outermost pending group is the widest, so it always overflows first
+	for len(l.pending) > 0 && l.pending[0].width(l.width) > l.maxWidth {
+		l.breakOutermost()
+	}
+}
+
+// breakOutermost breaks the outermost pending group and writes out every
+// held back token up to the next pending group
+func (l *layout) breakOutermost() {
+	l.pending[0].broken = true
+	l.pending = l.pending[1:]
+
+	var next *group
+	if len(l.pending) > 0 {
+		next = l.pending[0]
+	}
+	l.flush(next)
+}
+
+// flush writes held back tokens until the tokBegin of the until group, or
+// every held back token if until is nil
+func (l *layout) flush(until *group) {
+	i := 0
+	for ; i < len(l.buf); i++ {
+		if until != nil && l.buf[i].kind == tokBegin && l.buf[i].g == until {
+			break
+		}
+		l.write(l.buf[i])
+	}
+	l.buf = l.buf[i:]
+}
+
+// write renders a decided token to the output
+func (l *layout) write(t token) {
+	switch t.kind {
+	case tokText:
+		l.out.WriteString(t.text)
+	case tokBreak:
+		if len(l.modes) > 0 && l.modes[len(l.modes)-1] {
+			l.out.WriteString(t.text)
+		} else {
+			l.out.WriteByte('\n')
+			l.out.WriteString(strings.Repeat("  ", l.level))
+		}
+	case tokBrokenText:
+		if len(l.modes) == 0 || !l.modes[len(l.modes)-1] {
+			l.out.WriteString(t.text)
+		}
+	case tokBegin:
+		flat := !t.g.broken || (len(l.modes) > 0 && l.modes[len(l.modes)-1])
+		l.modes = append(l.modes, flat)
+	case tokEnd:
+		l.modes = l.modes[:len(l.modes)-1]
+	case tokIndent:
+		l.level++
+	case tokDedent:
+		l.level--
+	}
+}
+
+// compound lays out a braced, comma-separated list of items in a group
+type compound struct {
+	l          *layout
+	closeBrace string
+	padBraces  bool // whether the braces are padded with spaces in single-line format
+	items      int
+}
+
+// beginCompound opens a compound that breaks once it holds more than maxItems items
+func (l *layout) beginCompound(openBrace, closeBrace string, padBraces bool, maxItems int) compound {
+	l.begin(maxItems)
+	l.text(openBrace)
+	return compound{l: l, closeBrace: closeBrace, padBraces: padBraces}
+}
+
+// item starts the next item of the compound
+func (c *compound) item() {
+	if c.items == 0 {
+		c.l.indent()
+		c.l.brk(c.pad(), false)
+	} else {
+		c.l.text(",")
+		c.l.brk(" ", false)
+	}
+	c.items++
+	c.l.item()
+}
+
+// end closes the compound. The padding before the closing brace is not
+// counted towards the width of the compound.
+func (c *compound) end() {
+	if c.items > 0 {
+		c.l.dedent()
+		c.l.brk(c.pad(), true)
+	}
+	c.l.text(c.closeBrace)
+	c.l.end()
+}
+
+func (c *compound) pad() string {
+	if c.padBraces {
+		return " "
+	}
+	return ""
+}
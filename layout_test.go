@@ -0,0 +1,108 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLayoutGroups(t *testing.T) {
+	type Inner struct {
+		Values []int
+	}
+
+	type Outer struct {
+		Inner Inner
+		Name  string
+	}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		width    int
+		expected string
+	}{
+		{
+			name:     "fits exactly",
+			input:    []int{1, 2, 3, 4},
+			width:    12,
+			expected: "[1, 2, 3, 4]",
+		},
+		{
+			name:     "one over",
+			input:    []int{1, 2, 3, 4},
+			width:    11,
+			expected: "[\n  1,\n  2,\n  3,\n  4\n]",
+		},
+		{
+			name:     "closing padding is not counted",
+			input:    map[string]int{"a": 1, "b": 2},
+			width:    13,
+			expected: "{ a: 1, b: 2 }",
+		},
+		{
+			name:     "nested groups are decided on their own width",
+			input:    Outer{Inner: Inner{Values: []int{1, 2, 3}}, Name: "outer"},
+			width:    30,
+			expected: "Outer{\n  Inner: { Values: [1, 2, 3] },\n  Name: \"outer\"\n}",
+		},
+		{
+			name:     "broken child breaks its parent",
+			input:    [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+			width:    20,
+			expected: "[\n  [\n    1,\n    2,\n    3,\n    4,\n    5,\n    6,\n    7,\n    8,\n    9,\n    10\n  ]\n]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer := New().WithColorMode(ColorNever).WithMaxWidth(tt.width)
+			result := printer.Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLayoutMaxKeysInline(t *testing.T) {
+	printer := New().WithColorMode(ColorNever)
+	printer.MaxKeysInline = 2
+
+	data := map[string]interface{}{
+		"small": map[string]int{"a": 1, "b": 2},
+		"large": map[string]int{"a": 1, "b": 2, "c": 3},
+	}
+
+	result := printer.Print(data)
+	expected := "{\n  large: {\n    a: 1,\n    b: 2,\n    c: 3\n  },\n  small: { a: 1, b: 2 }\n}"
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
+func TestLayoutDeeplyNested(t *testing.T) {
+	// Each level used to be formatted twice, so this would never finish
+	type Node struct {
+		Value    int
+		Children []*Node
+	}
+
+	root := &Node{Value: 0}
+	node := root
+	for i := 1; i < 64; i++ {
+		child := &Node{Value: i}
+		node.Children = []*Node{child}
+		node = child
+	}
+
+	result := New().WithColorMode(ColorNever).WithMaxWidth(10).Print(root)
+	if !strings.Contains(result, "Value: 63") {
+		t.Errorf("Expected deepest node in result: %s", result)
+	}
+
+	lines := strings.Split(result, "\n")
+	last := lines[len(lines)-1]
+	if last != "}" {
+		t.Errorf("Expected output to close at the top level, got last line %q", last)
+	}
+}
@@ -0,0 +1,464 @@
+package pretty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PluralCategory is a CLDR plural category, which picks the form of a word
+// for a count. Languages use different categories: English has one and
+// other, Russian has one, few and many, and Japanese only other.
+type PluralCategory int
+
+const (
+	PluralOther PluralCategory = iota
+	PluralZero
+	PluralOne
+	PluralTwo
+	PluralFew
+	PluralMany
+)
+
+// Locale holds the phrases a TimeFormatter shows relative times with in a
+// language. Missing phrases and unit forms fall back to English.
+type Locale struct {
+	// Tag is the BCP 47 language tag of the locale, such as "de"
+	Tag string
+
+	// Plural returns the plural category of a count
+	// If nil, every count is PluralOther
+	Plural func(n int) PluralCategory
+
+	// Units are the forms of each unit by plural category, with %d for the
+	// count, such as "%d hours". The units are "second", "minute", "hour",
+	// "day", "week", "month" and "year". Forms missing a category fall back
+	// to PluralOther.
+	Units map[string]map[PluralCategory]string
+
+	// Abbreviations are the abbreviated forms of each unit, such as "%dh"
+	Abbreviations map[string]string
+
+	// UnitSeparator separates the units of a time, such as the space in
+	// "2 hours 13 minutes"
+	UnitSeparator string
+
+	// Past and Future are the templates of times in the past and in the
+	// future, such as "%s ago" and "in %s"
+	Past   string
+	Future string
+
+	// Phrases are the friendly phrases, keyed by their English text: "just
+	// now", "yesterday", "tomorrow", "last week", "next week", "last month",
+	// "next month", "last year", "next year", and the templates "yesterday
+	// at %s", "tomorrow at %s", "last %s" and "next %s" used by calendar mode.
+	// Phrases for single weekdays, such as "last Tuesday", take precedence
+	// over the templates, for languages whose words agree with the weekday.
+	Phrases map[string]string
+
+	// Weekdays are the names of the days of the week starting on Sunday, as
+	// used in the "last %s" and "next %s" phrases
+	Weekdays [7]string
+
+	// TimeOfDay is the time.Format layout of the time in "yesterday at %s"
+	TimeOfDay string
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{}
+)
+
+// RegisterLocale registers a locale under its Tag, replacing any locale
+// registered with the same tag
+func RegisterLocale(locale *Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[strings.ToLower(locale.Tag)] = locale
+}
+
+// LookupLocale returns the locale registered for a language tag, falling
+// back from a regional tag such as "de-AT" to its language "de"
+func LookupLocale(tag string) (*Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	for {
+		if locale, ok := locales[tag]; ok {
+			return locale, true
+		}
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			return nil, false
+		}
+		tag = tag[:i]
+	}
+}
+
+// pluralCategory returns the plural category of n in the locale
+func (l *Locale) pluralCategory(n int) PluralCategory {
+	if l.Plural == nil {
+		return PluralOther
+	}
+	return l.Plural(n)
+}
+
+// unit returns the form of n of a unit, such as "3 days"
+func (l *Locale) unit(n int, unit string) string {
+	forms := l.Units[unit]
+	if forms == nil {
+		l, forms = English, English.Units[unit]
+	}
+	form, ok := forms[l.pluralCategory(n)]
+	if !ok {
+		form = forms[PluralOther]
+	}
+	return sprintfCount(form, n)
+}
+
+// abbreviation returns the abbreviated form of n of a unit, such as "3d"
+func (l *Locale) abbreviation(n int, unit string) string {
+	form, ok := l.Abbreviations[unit]
+	if !ok {
+		form = English.Abbreviations[unit]
+	}
+	return sprintfCount(form, n)
+}
+
+// phrase returns the friendly phrase keyed by its English text
+func (l *Locale) phrase(key string) string {
+	if phrase, ok := l.Phrases[key]; ok {
+		return phrase
+	}
+	return English.Phrases[key]
+}
+
+// weekday returns the phrase for the last or next weekday, such as "last
+// Tuesday"
+func (l *Locale) weekday(isPast bool, day time.Weekday) string {
+	key := "next "
+	if isPast {
+		key = "last "
+	}
+	if phrase, ok := l.Phrases[key+day.String()]; ok {
+		return phrase
+	}
+	name := l.Weekdays[day]
+	if name == "" {
+		name = English.Weekdays[day]
+	}
+	return fmt.Sprintf(l.phrase(key+"%s"), name)
+}
+
+// sprintfCount replaces the %d in form with n
+func sprintfCount(form string, n int) string {
+	return strings.Replace(form, "%d", strconv.Itoa(n), 1)
+}
+
+// English is the default locale
+var English = &Locale{
+	Tag: "en",
+	Plural: func(n int) PluralCategory {
+		if n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	},
+	Units: map[string]map[PluralCategory]string{
+		"second": {PluralOne: "%d second", PluralOther: "%d seconds"},
+		"minute": {PluralOne: "%d minute", PluralOther: "%d minutes"},
+		"hour":   {PluralOne: "%d hour", PluralOther: "%d hours"},
+		"day":    {PluralOne: "%d day", PluralOther: "%d days"},
+		"week":   {PluralOne: "%d week", PluralOther: "%d weeks"},
+		"month":  {PluralOne: "%d month", PluralOther: "%d months"},
+		"year":   {PluralOne: "%d year", PluralOther: "%d years"},
+	},
+	Abbreviations: map[string]string{
+		"second": "%ds",
+		"minute": "%dm",
+		"hour":   "%dh",
+		"day":    "%dd",
+		"week":   "%dw",
+		"month":  "%dmo",
+		"year":   "%dy",
+	},
+	UnitSeparator: " ",
+	Past:          "%s ago",
+	Future:        "in %s",
+	Phrases: map[string]string{
+		"just now":        "just now",
+		"yesterday":       "yesterday",
+		"tomorrow":        "tomorrow",
+		"last week":       "last week",
+		"next week":       "next week",
+		"last month":      "last month",
+		"next month":      "next month",
+		"last year":       "last year",
+		"next year":       "next year",
+		"yesterday at %s": "yesterday at %s",
+		"tomorrow at %s":  "tomorrow at %s",
+		"last %s":         "last %s",
+		"next %s":         "next %s",
+	},
+	Weekdays:  [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	TimeOfDay: "3:04 PM",
+}
+
+// German is the locale of German
+var German = &Locale{
+	Tag:    "de",
+	Plural: English.Plural,
+	Units: map[string]map[PluralCategory]string{
+		"second": {PluralOne: "%d Sekunde", PluralOther: "%d Sekunden"},
+		"minute": {PluralOne: "%d Minute", PluralOther: "%d Minuten"},
+		"hour":   {PluralOne: "%d Stunde", PluralOther: "%d Stunden"},
+		"day":    {PluralOne: "%d Tag", PluralOther: "%d Tagen"},
+		"week":   {PluralOne: "%d Woche", PluralOther: "%d Wochen"},
+		"month":  {PluralOne: "%d Monat", PluralOther: "%d Monaten"},
+		"year":   {PluralOne: "%d Jahr", PluralOther: "%d Jahren"},
+	},
+	Abbreviations: map[string]string{
+		"second": "%ds",
+		"minute": "%dmin",
+		"hour":   "%dh",
+		"day":    "%dT",
+		"week":   "%dW",
+		"month":  "%dM",
+		"year":   "%dJ",
+	},
+	UnitSeparator: " ",
+	Past:          "vor %s",
+	Future:        "in %s",
+	Phrases: map[string]string{
+		"just now":        "gerade eben",
+		"yesterday":       "gestern",
+		"tomorrow":        "morgen",
+		"last week":       "letzte Woche",
+		"next week":       "nächste Woche",
+		"last month":      "letzten Monat",
+		"next month":      "nächsten Monat",
+		"last year":       "letztes Jahr",
+		"next year":       "nächstes Jahr",
+		"yesterday at %s": "gestern um %s",
+		"tomorrow at %s":  "morgen um %s",
+		"last %s":         "letzten %s",
+		"next %s":         "nächsten %s",
+	},
+	Weekdays:  [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	TimeOfDay: "15:04",
+}
+
+// French is the locale of French
+var French = &Locale{
+	Tag: "fr",
+	Plural: func(n int) PluralCategory {
+		if n == 0 || n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	},
+	Units: map[string]map[PluralCategory]string{
+		"second": {PluralOne: "%d seconde", PluralOther: "%d secondes"},
+		"minute": {PluralOne: "%d minute", PluralOther: "%d minutes"},
+		"hour":   {PluralOne: "%d heure", PluralOther: "%d heures"},
+		"day":    {PluralOne: "%d jour", PluralOther: "%d jours"},
+		"week":   {PluralOne: "%d semaine", PluralOther: "%d semaines"},
+		"month":  {PluralOne: "%d mois", PluralOther: "%d mois"},
+		"year":   {PluralOne: "%d an", PluralOther: "%d ans"},
+	},
+	Abbreviations: map[string]string{
+		"second": "%ds",
+		"minute": "%dmin",
+		"hour":   "%dh",
+		"day":    "%dj",
+		"week":   "%dsem",
+		"month":  "%dmois",
+		"year":   "%da",
+	},
+	UnitSeparator: " ",
+	Past:          "il y a %s",
+	Future:        "dans %s",
+	Phrases: map[string]string{
+		"just now":        "à l’instant",
+		"yesterday":       "hier",
+		"tomorrow":        "demain",
+		"last week":       "la semaine dernière",
+		"next week":       "la semaine prochaine",
+		"last month":      "le mois dernier",
+		"next month":      "le mois prochain",
+		"last year":       "l’année dernière",
+		"next year":       "l’année prochaine",
+		"yesterday at %s": "hier à %s",
+		"tomorrow at %s":  "demain à %s",
+		"last %s":         "%s dernier",
+		"next %s":         "%s prochain",
+	},
+	Weekdays:  [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	TimeOfDay: "15:04",
+}
+
+// Spanish is the locale of Spanish
+var Spanish = &Locale{
+	Tag:    "es",
+	Plural: English.Plural,
+	Units: map[string]map[PluralCategory]string{
+		"second": {PluralOne: "%d segundo", PluralOther: "%d segundos"},
+		"minute": {PluralOne: "%d minuto", PluralOther: "%d minutos"},
+		"hour":   {PluralOne: "%d hora", PluralOther: "%d horas"},
+		"day":    {PluralOne: "%d día", PluralOther: "%d días"},
+		"week":   {PluralOne: "%d semana", PluralOther: "%d semanas"},
+		"month":  {PluralOne: "%d mes", PluralOther: "%d meses"},
+		"year":   {PluralOne: "%d año", PluralOther: "%d años"},
+	},
+	Abbreviations: map[string]string{
+		"second": "%ds",
+		"minute": "%dmin",
+		"hour":   "%dh",
+		"day":    "%dd",
+		"week":   "%dsem",
+		"month":  "%dm",
+		"year":   "%da",
+	},
+	UnitSeparator: " ",
+	Past:          "hace %s",
+	Future:        "dentro de %s",
+	Phrases: map[string]string{
+		"just now":        "ahora mismo",
+		"yesterday":       "ayer",
+		"tomorrow":        "mañana",
+		"last week":       "la semana pasada",
+		"next week":       "la próxima semana",
+		"last month":      "el mes pasado",
+		"next month":      "el próximo mes",
+		"last year":       "el año pasado",
+		"next year":       "el próximo año",
+		"yesterday at %s": "ayer a las %s",
+		"tomorrow at %s":  "mañana a las %s",
+		"last %s":         "el %s pasado",
+		"next %s":         "el próximo %s",
+	},
+	Weekdays:  [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	TimeOfDay: "15:04",
+}
+
+// Russian is the locale of Russian, which has three plural forms for counts
+var Russian = &Locale{
+	Tag: "ru",
+	Plural: func(n int) PluralCategory {
+		n = max(n, -n)
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return PluralOne
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	},
+	Units: map[string]map[PluralCategory]string{
+		"second": {PluralOne: "%d секунду", PluralFew: "%d секунды", PluralMany: "%d секунд", PluralOther: "%d секунды"},
+		"minute": {PluralOne: "%d минуту", PluralFew: "%d минуты", PluralMany: "%d минут", PluralOther: "%d минуты"},
+		"hour":   {PluralOne: "%d час", PluralFew: "%d часа", PluralMany: "%d часов", PluralOther: "%d часа"},
+		"day":    {PluralOne: "%d день", PluralFew: "%d дня", PluralMany: "%d дней", PluralOther: "%d дня"},
+		"week":   {PluralOne: "%d неделю", PluralFew: "%d недели", PluralMany: "%d недель", PluralOther: "%d недели"},
+		"month":  {PluralOne: "%d месяц", PluralFew: "%d месяца", PluralMany: "%d месяцев", PluralOther: "%d месяца"},
+		"year":   {PluralOne: "%d год", PluralFew: "%d года", PluralMany: "%d лет", PluralOther: "%d года"},
+	},
+	Abbreviations: map[string]string{
+		"second": "%dс",
+		"minute": "%dмин",
+		"hour":   "%dч",
+		"day":    "%dд",
+		"week":   "%dнед",
+		"month":  "%dмес",
+		"year":   "%dг",
+	},
+	UnitSeparator: " ",
+	Past:          "%s назад",
+	Future:        "через %s",
+	Phrases: map[string]string{
+		"just now":        "только что",
+		"yesterday":       "вчера",
+		"tomorrow":        "завтра",
+		"last week":       "на прошлой неделе",
+		"next week":       "на следующей неделе",
+		"last month":      "в прошлом месяце",
+		"next month":      "в следующем месяце",
+		"last year":       "в прошлом году",
+		"next year":       "в следующем году",
+		"yesterday at %s": "вчера в %s",
+		"tomorrow at %s":  "завтра в %s",
+		"last %s":         "в прошлый %s",
+		"next %s":         "в следующий %s",
+
+		// Adjectives agree with the gender of the weekday
+		"last Sunday":    "в прошлое воскресенье",
+		"last Monday":    "в прошлый понедельник",
+		"last Tuesday":   "в прошлый вторник",
+		"last Wednesday": "в прошлую среду",
+		"last Thursday":  "в прошлый четверг",
+		"last Friday":    "в прошлую пятницу",
+		"last Saturday":  "в прошлую субботу",
+		"next Sunday":    "в следующее воскресенье",
+		"next Monday":    "в следующий понедельник",
+		"next Tuesday":   "в следующий вторник",
+		"next Wednesday": "в следующую среду",
+		"next Thursday":  "в следующий четверг",
+		"next Friday":    "в следующую пятницу",
+		"next Saturday":  "в следующую субботу",
+	},
+	Weekdays:  [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+	TimeOfDay: "15:04",
+}
+
+// Japanese is the locale of Japanese, which has no plural forms
+var Japanese = &Locale{
+	Tag: "ja",
+	Units: map[string]map[PluralCategory]string{
+		"second": {PluralOther: "%d秒"},
+		"minute": {PluralOther: "%d分"},
+		"hour":   {PluralOther: "%d時間"},
+		"day":    {PluralOther: "%d日"},
+		"week":   {PluralOther: "%d週間"},
+		"month":  {PluralOther: "%dか月"},
+		"year":   {PluralOther: "%d年"},
+	},
+	Abbreviations: map[string]string{
+		"second": "%d秒",
+		"minute": "%d分",
+		"hour":   "%d時間",
+		"day":    "%d日",
+		"week":   "%d週",
+		"month":  "%dか月",
+		"year":   "%d年",
+	},
+	UnitSeparator: "",
+	Past:          "%s前",
+	Future:        "%s後",
+	Phrases: map[string]string{
+		"just now":        "たった今",
+		"yesterday":       "昨日",
+		"tomorrow":        "明日",
+		"last week":       "先週",
+		"next week":       "来週",
+		"last month":      "先月",
+		"next month":      "来月",
+		"last year":       "昨年",
+		"next year":       "来年",
+		"yesterday at %s": "昨日 %s",
+		"tomorrow at %s":  "明日 %s",
+		"last %s":         "前の%s",
+		"next %s":         "次の%s",
+	},
+	Weekdays:  [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	TimeOfDay: "15:04",
+}
+
+func init() {
+	for _, locale := range []*Locale{English, German, French, Spanish, Russian, Japanese} {
+		RegisterLocale(locale)
+	}
+}
@@ -0,0 +1,142 @@
+package pretty
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeFormatter_Locale(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tf := NewTimeFormatter().WithNow(now)
+	de := tf.WithLocale(German)
+	ja := tf.WithLocale(Japanese)
+	ru := tf.WithLocale(Russian)
+
+	tests := []struct {
+		name     string
+		tf       *TimeFormatter
+		input    time.Time
+		expected string
+	}{
+		{"german just now", de, now.Add(-5 * time.Second), "gerade eben"},
+		{"german one", de, now.Add(-1 * time.Minute), "vor 1 Minute"},
+		{"german past", de, now.Add(-3 * 24 * time.Hour), "vor 3 Tagen"},
+		{"german future", de, now.Add(2 * time.Hour), "in 2 Stunden"},
+		{"german yesterday", de, now.Add(-24 * time.Hour), "gestern"},
+		{"german last week", de, now.Add(-7 * 24 * time.Hour), "letzte Woche"},
+		{"german precision", de.WithPrecision(2), now.Add(-(2*time.Hour + 13*time.Minute)), "vor 2 Stunden 13 Minuten"},
+		{"japanese past", ja, now.Add(-5 * time.Minute), "5分前"},
+		{"japanese future", ja, now.Add(3 * 24 * time.Hour), "3日後"},
+		{"japanese precision", ja.WithPrecision(2), now.Add(-(2*time.Hour + 13*time.Minute)), "2時間13分前"},
+		{"japanese next month", ja, now.Add(30 * 24 * time.Hour), "来月"},
+		{"russian one", ru, now.Add(-21 * time.Minute), "21 минуту назад"},
+		{"russian few", ru, now.Add(-3 * time.Minute), "3 минуты назад"},
+		{"russian many", ru, now.Add(-11 * time.Minute), "11 минут назад"},
+		{"russian future", ru, now.Add(5 * time.Hour), "через 5 часов"},
+		{"russian abbreviated", ru.WithAbbreviated(true), now.Add(-5 * time.Hour), "5ч назад"},
+		{"future format overrides", de.WithFutureFormat("%s from now"), now.Add(2 * time.Hour), "2 Stunden from now"},
+		{"nil is english", tf.WithLocale(nil), now.Add(-2 * time.Hour), "2 hours ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.tf.Format(tt.input)
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeFormatter_LocaleCalendar(t *testing.T) {
+	// Friday noon
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		locale   *Locale
+		input    time.Time
+		expected string
+	}{
+		{"german yesterday", German, time.Date(2026, 10, 15, 23, 4, 0, 0, time.UTC), "gestern um 23:04"},
+		{"german last weekday", German, time.Date(2026, 10, 13, 18, 0, 0, 0, time.UTC), "letzten Dienstag"},
+		{"french next weekday", French, time.Date(2026, 10, 21, 8, 0, 0, 0, time.UTC), "mercredi prochain"},
+		{"spanish tomorrow", Spanish, time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC), "mañana a las 09:30"},
+		{"japanese last weekday", Japanese, time.Date(2026, 10, 13, 18, 0, 0, 0, time.UTC), "前の火曜日"},
+		{"russian last weekday", Russian, time.Date(2026, 10, 13, 18, 0, 0, 0, time.UTC), "в прошлый вторник"},
+		{"russian next weekday", Russian, time.Date(2026, 10, 21, 8, 0, 0, 0, time.UTC), "в следующую среду"},
+		{"russian months", Russian, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), "5 месяцев назад"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf := NewTimeFormatter().WithNow(now).WithCalendar(true).WithLocale(tt.locale)
+			result := tf.Format(tt.input)
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocalePlural(t *testing.T) {
+	tests := []struct {
+		locale   *Locale
+		n        int
+		expected PluralCategory
+	}{
+		{English, 1, PluralOne},
+		{English, 0, PluralOther},
+		{French, 0, PluralOne},
+		{French, 2, PluralOther},
+		{Russian, 1, PluralOne},
+		{Russian, 11, PluralMany},
+		{Russian, 22, PluralFew},
+		{Russian, 14, PluralMany},
+		{Russian, 101, PluralOne},
+		{Japanese, 1, PluralOther},
+	}
+
+	for _, tt := range tests {
+		if got := tt.locale.pluralCategory(tt.n); got != tt.expected {
+			t.Errorf("%s.Plural(%d) = %v, want %v", tt.locale.Tag, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	if locale, ok := LookupLocale("de-AT"); !ok || locale != German {
+		t.Errorf("LookupLocale(%q) = %v, %v, want German", "de-AT", locale, ok)
+	}
+	if _, ok := LookupLocale("xx"); ok {
+		t.Errorf("LookupLocale(%q) found a locale, want none", "xx")
+	}
+
+	// Missing phrases fall back to English
+	RegisterLocale(&Locale{
+		Tag:    "x-pirate",
+		Plural: English.Plural,
+		Units: map[string]map[PluralCategory]string{
+			"day": {PluralOne: "%d sunrise", PluralOther: "%d sunrises"},
+		},
+		UnitSeparator: " ",
+		Past:          "%s back",
+		Future:        "%s hence",
+	})
+	pirate, ok := LookupLocale("X-Pirate")
+	if !ok {
+		t.Fatalf("LookupLocale(%q) found no locale", "X-Pirate")
+	}
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tf := NewTimeFormatter().WithNow(now).WithLocale(pirate)
+	for input, expected := range map[time.Time]string{
+		now.Add(-3 * 24 * time.Hour): "3 sunrises back",
+		now.Add(2 * time.Hour):       "2 hours hence",
+		now.Add(-24 * time.Hour):     "yesterday",
+	} {
+		if result := tf.Format(input); result != expected {
+			t.Errorf("Format() = %q, want %q", result, expected)
+		}
+	}
+}
@@ -12,9 +12,14 @@
 // Or using the fluent API:
 //
 //	pretty.New().WithMaxWidth(50).Print(data)
+//
+// Or streaming straight to a writer:
+//
+//	pretty.Fprintln(os.Stdout, data)
 package pretty
 
 import (
+	"bufio"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
@@ -24,6 +29,7 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,7 +64,8 @@ var (
 	styleComment     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))  // gray - for comments/metadata
 	styleTime        = lipgloss.NewStyle().Foreground(lipgloss.Color("13")) // bright magenta - for times
 	stylePointer     = lipgloss.NewStyle().Foreground(lipgloss.Color("88"))
-	styleField       = lipgloss.NewStyle() // no styling - for field names
+	styleField       = lipgloss.NewStyle()             // no styling - for field names
+	styleUnexported  = lipgloss.NewStyle().Faint(true) // dim - for unexported field names
 
 	pointerGamut = []lipgloss.Style{
 		lipgloss.NewStyle().Foreground(lipgloss.Color("196")), // Red
@@ -74,7 +81,8 @@ var (
 )
 
 var (
-	timeType = reflect.TypeOf(time.Time{})
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
 
 	Default = New()
 )
@@ -88,6 +96,22 @@ type Printer struct {
 	// MaxSliceLength is the maximum number of elements to show in slices/arrays
 	// If 0, shows all elements (default behavior)
 	MaxSliceLength int
+	// TruncateStrategy controls which elements of slices longer than
+	// MaxSliceLength are shown, and can be set for a field with its tag
+	// If TruncateHeadTail, the first and last elements are shown (default behavior)
+	TruncateStrategy TruncateStrategy
+	// BytesMode controls how byte slices and arrays are shown
+	// If BytesAuto, they are shown as text, JSON or a hexdump (default behavior)
+	BytesMode BytesMode
+	// MaxBytes is the maximum number of bytes to show of byte slices and arrays
+	// If 0, shows all bytes
+	MaxBytes int
+	// MaxMapKeys is the maximum number of keys to show in maps, in sorted order
+	// If 0, shows all keys (default behavior)
+	MaxMapKeys int
+	// MaxStructFields is the maximum number of fields to show in structs
+	// If 0, shows all fields (default behavior)
+	MaxStructFields int
 	// MaxStringLength is the maximum length for individual strings before truncation
 	// If 0, no truncation is applied (default behavior)
 	MaxStringLength int
@@ -95,6 +119,54 @@ type Printer struct {
 	// inline before breaking to multiple lines. This allows maps and structs to
 	// be multi-lined before the overall max width is reached.
 	MaxKeysInline int
+	// StringerMode controls when values are formatted with their String or
+	// MarshalText methods. Errors are always formatted with their messages.
+	// If StringerNever, values are always shown as they are (default behavior)
+	StringerMode StringerMode
+	// MaxDepth is the number of levels of nested structs, maps, slices and
+	// arrays shown. Values nested deeper are collapsed into a summary, such as
+	// User{… 14 fields}.
+	// If 0, values are shown at any depth (default behavior)
+	MaxDepth int
+	// ShowUnexported shows the unexported fields of structs as well. Their
+	// methods can't be called, so they are shown by their fields rather than by
+	// custom formatters, String methods or error messages.
+	// If false, only exported fields are shown (default behavior)
+	ShowUnexported bool
+	// TableMode controls when slices of structs and maps are laid out as tables
+	// If TableNever, they are laid out as lists (default behavior)
+	TableMode TableMode
+	// Redactor decides which values are secrets to be redacted, see NewRedactor.
+	// If nil, only fields tagged with `pretty:"secret"` are (default behavior)
+	Redactor *Redactor
+	// DiffOptions configure how Diff compares values
+	DiffOptions DiffOptions
+	// GoSyntax formats values as gofmt formatted Go expressions that can be
+	// pasted into Go source, such as pkg.User{Name: "Alice"}. Values that
+	// can't be written as literals, such as cycles and unexported fields, are
+	// explained by comments instead. The output is never colored.
+	GoSyntax bool
+	// SequentialIDs marks the values in cycles with #1, #2… in the order they
+	// are reached, instead of with a hash of their address, so that the same
+	// value prints the same on every run
+	SequentialIDs bool
+	// TimeMode controls whether times are shown relative to now, with the
+	// TimeLayout, or both
+	// If TimeRelative, times are shown relative to now (default behavior)
+	TimeMode TimeMode
+	// TimeLayout is the time.Format layout of absolute times
+	// If empty, DefaultTimeLayout is used (default behavior)
+	TimeLayout string
+	// TimeFormatter formats times relative to now. Its Now is replaced by the
+	// current time from the Clock.
+	// If nil, NewTimeFormatter is used (default behavior)
+	TimeFormatter *TimeFormatter
+	// TimeLocation is the location times are converted to before they are shown
+	// If nil, times are shown in their own location (default behavior)
+	TimeLocation *time.Location
+	// Clock returns the current time that times are shown relative to
+	// If nil, time.Now is used (default behavior)
+	Clock func() time.Time
 	// Margin adds space around the output
 	// If 0, no margin is applied (default behavior)
 	Margin [4]int
@@ -111,11 +183,40 @@ type Printer struct {
 		Null        lipgloss.Style // for nil/null values
 		Comment     lipgloss.Style // for comments and metadata
 		Field       lipgloss.Style // for field names (struct fields and string map keys)
+		Unexported  lipgloss.Style // for the names of unexported struct fields
 		Pointer     lipgloss.Style // for pointers
 	}
 
+	// formatters holds the custom formatters by type, see RegisterFormatter
+	formatters map[reflect.Type]func(any) string
+}
+
+// state holds the traversal state of a single call, so that one Printer can
+// be used by any number of goroutines at once
+type state struct {
+	*layout
+
 	visited map[uintptr]bool
 	cycled  map[uintptr]bool
+	ids     map[uintptr]int // sequential IDs of cycled values, see SequentialIDs
+	depth   int             // number of structs, maps, slices and arrays the value is in, see MaxDepth
+
+	truncate      TruncateStrategy // strategy of the slice at truncateDepth, set by its field tag
+	truncateDepth int              // depth of the slice with a strategy, or 0 if none
+}
+
+// withLayout returns a copy of the state that writes to l, sharing the
+// traversal state with s
+func (s *state) withLayout(l *layout) *state {
+	return &state{
+		layout:        l,
+		visited:       s.visited,
+		cycled:        s.cycled,
+		ids:           s.ids,
+		depth:         s.depth,
+		truncate:      s.truncate,
+		truncateDepth: s.truncateDepth,
+	}
 }
 
 // New creates a new Printer with default options
@@ -124,6 +225,7 @@ func New() *Printer {
 		MaxWidth:        defaultWidth,
 		ColorMode:       ColorAuto,
 		MaxSliceLength:  20,
+		MaxBytes:        64,
 		MaxStringLength: 0, // No string truncation by default
 		Margin:          [4]int{0, 0, 0, 0},
 	}
@@ -139,6 +241,7 @@ func New() *Printer {
 	p.Styles.Null = styleNull
 	p.Styles.Comment = styleComment
 	p.Styles.Field = styleField
+	p.Styles.Unexported = styleUnexported
 	p.Styles.Pointer = stylePointer
 
 	return p
@@ -146,22 +249,95 @@ func New() *Printer {
 
 // Print formats any input value into a pretty-printed string representation
 func (p *Printer) Print(v interface{}) string {
-	if v == nil {
-		return p.colorize("nil", p.Styles.Null)
+	var sb strings.Builder
+	p.format(&sb, v)
+
+	if p.hasMargin() {
+		style := lipgloss.NewStyle().Margin(p.Margin[0], p.Margin[1], p.Margin[2], p.Margin[3])
+		return style.Render(sb.String())
+	}
+
+	return sb.String()
+}
+
+// Fprint formats any input value and writes it to w as it is produced. It
+// returns the number of bytes written and any write error encountered.
+//
+// With ColorAuto, colors are only used when w is a terminal. Output with a
+// margin is rendered in full before it is written.
+func (p *Printer) Fprint(w io.Writer, v interface{}) (n int, err error) {
+	if p.ColorMode == ColorAuto {
+		p = p.WithColorMode(colorModeFor(w))
+	}
+
+	if p.hasMargin() {
+		return io.WriteString(w, p.Print(v))
 	}
 
-	val := reflect.ValueOf(v)
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	p.format(bw, v)
+	err = bw.Flush()
+	return cw.n, err
+}
 
-	p.visited = make(map[uintptr]bool)
-	p.cycled = make(map[uintptr]bool)
-	defer clear(p.visited)
+// Fprintln is like Fprint but adds a trailing newline
+func (p *Printer) Fprintln(w io.Writer, v interface{}) (n int, err error) {
+	n, err = p.Fprint(w, v)
+	if err != nil {
+		return n, err
+	}
+	m, err := io.WriteString(w, "\n")
+	return n + m, err
+}
 
-	if p.Margin[0] != 0 || p.Margin[1] != 0 || p.Margin[2] != 0 || p.Margin[3] != 0 {
-		style := lipgloss.NewStyle().Margin(p.Margin[0], p.Margin[1], p.Margin[2], p.Margin[3])
-		return style.Render(p.formatValue(val, 0))
+// format lays out v into out, starting a fresh traversal
+func (p *Printer) format(out writer, v interface{}) {
+	if v == nil {
+		out.WriteString(p.colorize("nil", p.Styles.Null))
+		return
 	}
 
-	return p.formatValue(val, 0)
+	if p.GoSyntax {
+		p.formatGoSource(out, v)
+		return
+	}
+	p.formatValue(p.newState(out), reflect.ValueOf(v))
+}
+
+// newState starts the state of a fresh traversal that writes to out
+func (p *Printer) newState(out writer) *state {
+	return &state{
+		layout:  newLayout(out, p.MaxWidth),
+		visited: make(map[uintptr]bool),
+		cycled:  make(map[uintptr]bool),
+		ids:     make(map[uintptr]int),
+	}
+}
+
+// sprint lays out a single value on its own as part of the traversal in s,
+// and returns the result as a string
+func (p *Printer) sprint(s *state, val reflect.Value) string {
+	var sb strings.Builder
+	p.formatValue(s.withLayout(newLayout(&sb, p.MaxWidth)), val)
+	return sb.String()
+}
+
+// hasMargin reports whether any margin is applied around the output
+func (p *Printer) hasMargin() bool {
+	return p.Margin != [4]int{}
+}
+
+// countingWriter counts the bytes successfully written to w
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	cw.n += n
+	return n, err
 }
 
 // copyPrinter creates a copy of the printer with optional field overrides
@@ -191,6 +367,20 @@ func (p *Printer) WithMaxSliceLength(maxLen int) *Printer {
 	return newP
 }
 
+// WithMaxMapKeys creates a new Printer with the specified maximum number of map keys
+func (p *Printer) WithMaxMapKeys(maxKeys int) *Printer {
+	newP := p.copyPrinter()
+	newP.MaxMapKeys = maxKeys
+	return newP
+}
+
+// WithMaxStructFields creates a new Printer with the specified maximum number of struct fields
+func (p *Printer) WithMaxStructFields(maxFields int) *Printer {
+	newP := p.copyPrinter()
+	newP.MaxStructFields = maxFields
+	return newP
+}
+
 // WithMaxStringLength creates a new Printer with the specified maximum string length
 func (p *Printer) WithMaxStringLength(maxLen int) *Printer {
 	newP := p.copyPrinter()
@@ -198,30 +388,83 @@ func (p *Printer) WithMaxStringLength(maxLen int) *Printer {
 	return newP
 }
 
+// WithShowUnexported creates a new Printer that shows unexported struct fields
+func (p *Printer) WithShowUnexported(show bool) *Printer {
+	newP := p.copyPrinter()
+	newP.ShowUnexported = show
+	return newP
+}
+
+// WithSequentialIDs creates a new Printer that marks values in cycles with
+// sequential IDs instead of hashes of their addresses
+func (p *Printer) WithSequentialIDs(enabled bool) *Printer {
+	newP := p.copyPrinter()
+	newP.SequentialIDs = enabled
+	return newP
+}
+
+// WithClock creates a new Printer that shows times relative to the time
+// returned by clock
+func (p *Printer) WithClock(clock func() time.Time) *Printer {
+	newP := p.copyPrinter()
+	newP.Clock = clock
+	return newP
+}
+
+// WithTimeMode creates a new Printer with the specified time mode
+func (p *Printer) WithTimeMode(mode TimeMode) *Printer {
+	newP := p.copyPrinter()
+	newP.TimeMode = mode
+	return newP
+}
+
+// WithTimeLayout creates a new Printer that shows absolute times with layout
+func (p *Printer) WithTimeLayout(layout string) *Printer {
+	newP := p.copyPrinter()
+	newP.TimeLayout = layout
+	return newP
+}
+
+// WithTimeFormatter creates a new Printer that formats relative times with tf
+func (p *Printer) WithTimeFormatter(tf *TimeFormatter) *Printer {
+	newP := p.copyPrinter()
+	newP.TimeFormatter = tf
+	return newP
+}
+
+// WithTimeLocation creates a new Printer that shows times in loc
+func (p *Printer) WithTimeLocation(loc *time.Location) *Printer {
+	newP := p.copyPrinter()
+	newP.TimeLocation = loc
+	return newP
+}
+
+// WithMargin creates a new Printer with the specified margin around the output
 func (p *Printer) WithMargin(margin ...int) *Printer {
+	newP := p.copyPrinter()
 	switch len(margin) {
 	case 1:
-		p.Margin[0] = margin[0]
-		p.Margin[1] = margin[0]
-		p.Margin[2] = margin[0]
-		p.Margin[3] = margin[0]
+		newP.Margin[0] = margin[0]
+		newP.Margin[1] = margin[0]
+		newP.Margin[2] = margin[0]
+		newP.Margin[3] = margin[0]
 	case 2:
-		p.Margin[0] = margin[0]
-		p.Margin[1] = margin[1]
-		p.Margin[2] = margin[0]
-		p.Margin[3] = margin[1]
+		newP.Margin[0] = margin[0]
+		newP.Margin[1] = margin[1]
+		newP.Margin[2] = margin[0]
+		newP.Margin[3] = margin[1]
 	case 3:
-		p.Margin[0] = margin[0]
-		p.Margin[1] = margin[1]
-		p.Margin[2] = margin[2]
-		p.Margin[3] = margin[0]
+		newP.Margin[0] = margin[0]
+		newP.Margin[1] = margin[1]
+		newP.Margin[2] = margin[2]
+		newP.Margin[3] = margin[0]
 	case 4:
-		p.Margin[0] = margin[0]
-		p.Margin[1] = margin[1]
-		p.Margin[2] = margin[2]
-		p.Margin[3] = margin[3]
+		newP.Margin[0] = margin[0]
+		newP.Margin[1] = margin[1]
+		newP.Margin[2] = margin[2]
+		newP.Margin[3] = margin[3]
 	}
-	return p
+	return newP
 }
 
 // shouldUseColors determines if colors should be used based on the color mode
@@ -238,6 +481,14 @@ func (p *Printer) shouldUseColors() bool {
 	}
 }
 
+// colorModeFor resolves ColorAuto for output written to w
+func colorModeFor(w io.Writer) ColorMode {
+	if f, ok := w.(*os.File); ok && isTerminal(f) {
+		return ColorAlways
+	}
+	return ColorNever
+}
+
 // isTerminal checks if the given file is a terminal
 func isTerminal(f *os.File) bool {
 	fileInfo, err := f.Stat()
@@ -255,139 +506,19 @@ func (p *Printer) colorize(text string, style lipgloss.Style) string {
 	return style.Render(text)
 }
 
-// compoundFormatter handles single-line vs multi-line formatting for compound types
-type compoundFormatter struct {
-	p             *Printer
-	openBrace     string
-	closeBrace    string
-	typeName      string
-	singleItems   []string
-	multiItems    []string
-	indent        int
-	currentWidth  int  // Running tally of visible width
-	exceedsWidth  bool // Early escape flag when width is exceeded
-	padBraces     bool // Whether to pad the braces with spaces in single-line format
-	maxKeysInline int
-}
-
-// newCompoundFormatter creates a new compound formatter
-func (p *Printer) newCompoundFormatter(openBrace, closeBrace, typeName string, indent int, padBraces bool, maxKeysInline int) *compoundFormatter {
-	cf := &compoundFormatter{
-		p:             p,
-		openBrace:     openBrace,
-		closeBrace:    closeBrace,
-		typeName:      typeName,
-		indent:        indent,
-		padBraces:     padBraces,
-		maxKeysInline: maxKeysInline,
-	}
-
-	// Initialize width with opening elements
-	padded := 0
-	if padBraces {
-		padded = 1
-	}
-	cf.currentWidth = lipgloss.Width(typeName+openBrace) + padded
-
-	return cf
-}
-
-// addItem adds an item to both single and multi-line formats
-func (cf *compoundFormatter) addItem(singleItem, multiItem string) {
-	// Early escape optimization: if we already exceed width, skip single-line processing
-	if !cf.exceedsWidth {
-		itemWidth := lipgloss.Width(singleItem)
-
-		// Add separator width (", ") for non-first items
-		if len(cf.singleItems) > 0 {
-			itemWidth += 2
-		}
-
-		cf.currentWidth += itemWidth
-
-		// Check if we've reached the maximum number of keys to show inline
-		if cf.maxKeysInline > 0 && len(cf.singleItems) >= cf.maxKeysInline {
-			cf.exceedsWidth = true
-		} else {
-			// Check if adding this item would exceed the width limit
-			closingWidth := lipgloss.Width(cf.closeBrace)
-			if cf.currentWidth+closingWidth > cf.p.MaxWidth {
-				cf.exceedsWidth = true
-			} else {
-				cf.singleItems = append(cf.singleItems, singleItem)
-			}
-		}
-	}
-
-	// Always add to multi-line items for fallback
-	cf.multiItems = append(cf.multiItems, multiItem)
-}
-
-// format returns the final formatted string, choosing single or multi-line based on width
-func (cf *compoundFormatter) format() string {
-	if len(cf.multiItems) == 0 {
-		if cf.typeName != "" {
-			return cf.typeName + cf.openBrace + cf.closeBrace
-		}
-		return cf.openBrace + cf.closeBrace
-	}
-
-	// If we exceeded width during processing or don't have all items in single format, use multi-line
-	if cf.exceedsWidth || len(cf.singleItems) != len(cf.multiItems) {
-		return cf.formatMultiLine()
-	}
-
-	// Build single line using pre-calculated width knowledge
-	var sb strings.Builder
-	if cf.typeName != "" {
-		sb.WriteString(cf.typeName)
-	}
-	sb.WriteString(cf.openBrace)
-	if cf.padBraces {
-		sb.WriteString(" ")
-	}
-	for i, item := range cf.singleItems {
-		if i > 0 {
-			sb.WriteString(", ")
-		}
-		sb.WriteString(item)
-	}
-	if cf.padBraces {
-		sb.WriteString(" ")
+// isSpecialHandledType checks if a value is a special type that should bypass struct formatting
+func (p *Printer) isSpecialHandledType(val reflect.Value) bool {
+	val = p.unwrapInterface(val)
+	if val.Type() == timeType || val.Type() == durationType || p.formatterFor(val) != nil {
+		return true
 	}
-	sb.WriteString(cf.closeBrace)
-
-	return sb.String()
-}
-
-// formatMultiLine formats the compound structure in multi-line format
-func (cf *compoundFormatter) formatMultiLine() string {
-	var sb strings.Builder
-	if cf.typeName != "" {
-		sb.WriteString(cf.typeName)
+	if _, ok := p.prettyFormatterFor(val); ok {
+		return true
 	}
-	sb.WriteString(cf.openBrace)
-	sb.WriteByte('\n')
-
-	indentStr := strings.Repeat("  ", cf.indent+1)
-	for i, item := range cf.multiItems {
-		if i > 0 {
-			sb.WriteString(",\n")
-		}
-		sb.WriteString(indentStr)
-		sb.WriteString(item)
+	if isError(val) {
+		return true
 	}
-
-	sb.WriteByte('\n')
-	sb.WriteString(strings.Repeat("  ", cf.indent))
-	sb.WriteString(cf.closeBrace)
-
-	return sb.String()
-}
-
-// isSpecialHandledType checks if a value is a special type that should bypass struct formatting
-func (p *Printer) isSpecialHandledType(val reflect.Value) bool {
-	return val.Type() == timeType
+	return p.isStringer(val)
 }
 
 // unwrapInterface extracts the underlying value from interface wrappers
@@ -438,8 +569,29 @@ func Print(v interface{}) string {
 	return Default.Print(v)
 }
 
-// formatCyclePointer formats a pointer value for cycle display using Base64 encoding
-func (p *Printer) formatCyclePointer(ptr uintptr) string {
+// Fprint formats any input value and writes it to w using default options
+func Fprint(w io.Writer, v interface{}) (n int, err error) {
+	return Default.Fprint(w, v)
+}
+
+// Fprintln formats any input value and writes it to w with a trailing newline using default options
+func Fprintln(w io.Writer, v interface{}) (n int, err error) {
+	return Default.Fprintln(w, v)
+}
+
+// formatCyclePointer formats a pointer value for cycle display using Base64
+// encoding, or its sequential ID with SequentialIDs
+func (p *Printer) formatCyclePointer(s *state, ptr uintptr) string {
+	if p.SequentialIDs {
+		id, ok := s.ids[ptr]
+		if !ok {
+			id = len(s.ids) + 1
+			s.ids[ptr] = id
+		}
+		style := pointerGamut[(id-1)%len(pointerGamut)]
+		return p.colorize("#", p.Styles.Comment) + p.colorize(strconv.Itoa(id), style)
+	}
+
 	// Hash the pointer to ensure visual distinction between similar pointers
 	hasher := fnv.New64a()
 	binary.Write(hasher, binary.LittleEndian, uint64(ptr))
@@ -582,51 +734,48 @@ func (p *Printer) tryFormatAsUUID(val reflect.Value) string {
 }
 
 // appendCyclePointerIfNeeded checks if a value is cycled and appends pointer display
-func (p *Printer) appendCyclePointerIfNeeded(formatted string, val reflect.Value) string {
+func (p *Printer) appendCyclePointerIfNeeded(s *state, val reflect.Value) {
 	if !p.canFormCycles(val) {
-		return formatted
+		return
 	}
 
 	var ptr uintptr
 	switch val.Kind() {
 	case reflect.Ptr, reflect.Map, reflect.Slice:
 		if val.IsNil() {
-			return formatted
+			return
 		}
 		ptr = val.Pointer()
 	case reflect.Interface:
 		// For interfaces, don't append cycle pointers here.
 		// The underlying value will handle its own cycle detection and pointer annotation.
-		return formatted
+		return
 	case reflect.Struct:
 		// For structs, only track cycle if it's a pointer to a struct
 		// Non-pointer structs can't form cycles as they are copied by value
 	}
 
-	if ptr != 0 && p.cycled[ptr] {
-		return formatted + p.formatCyclePointer(ptr)
+	if ptr != 0 && s.cycled[ptr] {
+		s.text(p.formatCyclePointer(s, ptr))
 	}
-
-	return formatted
 }
 
 func PrintWidth(v interface{}, width int) string {
 	return New().WithMaxWidth(width).Print(v)
 }
 
-// formatValue recursively formats a reflect.Value with proper indentation
-func (p *Printer) formatValue(val reflect.Value, indent int) string {
-	return p.formatValueWithOptions(val, indent, true)
+// formatValue recursively formats a reflect.Value into the layout
+func (p *Printer) formatValue(s *state, val reflect.Value) {
+	p.formatValueWithOptions(s, val, true)
 }
 
 // formatValueWithOptions recursively formats a reflect.Value with formatting options
-func (p *Printer) formatValueWithOptions(val reflect.Value, indent int, includeStructNames bool) string {
+func (p *Printer) formatValueWithOptions(s *state, val reflect.Value, includeStructNames bool) {
 	if !val.IsValid() {
-		return p.colorize("invalid", p.Styles.Error)
+		s.text(p.colorize("invalid", p.Styles.Error))
+		return
 	}
 
-	var result string
-
 	// Check for cycles in pointer-like types that can form circular references
 	if p.canFormCycles(val) {
 		var ptr uintptr
@@ -652,33 +801,81 @@ func (p *Printer) formatValueWithOptions(val reflect.Value, indent int, includeS
 		}
 
 		if ptr != 0 {
-			if p.visited[ptr] {
+			if s.visited[ptr] {
 				// Mark this pointer as part of a cycle, but continue with normal formatting
-				p.cycled[ptr] = true
+				s.cycled[ptr] = true
 				// Return a placeholder for cycled reference
-				return p.colorize("→", p.Styles.Comment) + p.formatCyclePointer(ptr)
+				s.text(p.colorize("→", p.Styles.Comment) + p.formatCyclePointer(s, ptr))
+				return
 			}
 			// Mark this address as visited
-			p.visited[ptr] = true
+			s.visited[ptr] = true
 			// Make sure to clean up after processing this level otherwise we'll prune
 			// all further references to this value, despite it not being a cycle.
 			// We do NOT clean up the cycled map, because we want to track when a
 			// "node" is omitted, and then tag the non-omitted nodes with their ptr.
-			defer delete(p.visited, ptr)
+			defer delete(s.visited, ptr)
 		}
 	}
 
+	// Custom formatters take precedence over any built-in formatting
+	if fn := p.formatterFor(val); fn != nil {
+		s.text(fn(val.Interface()))
+		p.appendCyclePointerIfNeeded(s, val)
+		return
+	}
+
+	// Types that describe their own representation are laid out like any other
+	if f, ok := p.prettyFormatterFor(val); ok {
+		f.PrettyFormat(p).layout(p, s)
+		p.appendCyclePointerIfNeeded(s, val)
+		return
+	}
+
 	// Check if the value implements io.ReadCloser
 	if val.IsValid() && val.CanInterface() {
 		if _, ok := val.Interface().(io.ReadCloser); ok {
-			result = p.colorize("<io.ReadCloser>", p.Styles.SpecialType)
-			return p.appendCyclePointerIfNeeded(result, val)
+			s.text(p.colorize("<io.ReadCloser>", p.Styles.SpecialType))
+			p.appendCyclePointerIfNeeded(s, val)
+			return
 		}
 	}
 
-	if val.Type() == timeType {
-		result = p.formatTime(val.Interface().(time.Time))
-		return p.appendCyclePointerIfNeeded(result, val)
+	// Errors show their message and the chain of errors they wrap
+	if err, msg, ok := p.errorFor(val); ok {
+		p.formatError(s, val, err, msg)
+		p.appendCyclePointerIfNeeded(s, val)
+		return
+	}
+
+	if val.Type() == timeType && val.CanInterface() {
+		s.text(p.formatTime(val.Interface().(time.Time)))
+		p.appendCyclePointerIfNeeded(s, val)
+		return
+	}
+
+	if val.Type() == durationType {
+		s.text(p.formatDuration(time.Duration(val.Int())))
+		p.appendCyclePointerIfNeeded(s, val)
+		return
+	}
+
+	if str, ok := p.stringFor(val); ok {
+		s.text(p.formatStringer(val.Type(), str))
+		p.appendCyclePointerIfNeeded(s, val)
+		return
+	}
+
+	// Values nested deeper than MaxDepth are collapsed into a summary
+	if p.shouldCollapse(s, val) {
+		s.text(p.formatCollapsed(val, includeStructNames))
+		p.appendCyclePointerIfNeeded(s, val)
+		return
+	}
+	switch val.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		s.depth++
+		defer func() { s.depth-- }()
 	}
 
 	switch val.Kind() {
@@ -687,78 +884,96 @@ func (p *Printer) formatValueWithOptions(val reflect.Value, indent int, includeS
 
 		// Check if string is a valid UUID and format it with pointer gamut coloring
 		if isUUIDString(str) {
-			result = p.formatUUIDString(str)
-		} else if js, ok := p.isJSON(str); ok {
-			// Check if string is valid JSON and pretty-print it
-			if prettyJSON := p.formatJSON(js, indent); prettyJSON != "" {
-				result = prettyJSON
-			}
+			s.text(p.formatUUIDString(str))
+			break
 		}
 
-		if result == "" {
-			// Apply string truncation if needed
-			truncatedStr := p.truncateString(str)
-			result = p.colorize(fmt.Sprintf(`"%s"`, truncatedStr), p.Styles.String)
+		// Check if string is valid JSON and pretty-print it
+		if js, ok := p.isJSON(str); ok && p.formatJSON(s, js) {
+			break
 		}
 
+		if p.isSecretString(str) {
+			p.formatRedacted(s, val)
+			break
+		}
+
+		// Apply string truncation if needed
+		truncatedStr := p.truncateString(str)
+		s.text(p.colorize(fmt.Sprintf(`"%s"`, truncatedStr), p.Styles.String))
+
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		result = p.colorize(fmt.Sprintf("%d", val.Int()), p.Styles.Number)
+		s.text(p.colorize(fmt.Sprintf("%d", val.Int()), p.Styles.Number))
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		result = p.colorize(fmt.Sprintf("%d", val.Uint()), p.Styles.Number)
+		s.text(p.colorize(fmt.Sprintf("%d", val.Uint()), p.Styles.Number))
 
 	case reflect.Float32, reflect.Float64:
-		result = p.colorize(fmt.Sprintf("%g", val.Float()), p.Styles.Float)
+		s.text(p.colorize(fmt.Sprintf("%g", val.Float()), p.Styles.Float))
 
 	case reflect.Bool:
-		result = p.colorize(fmt.Sprintf("%t", val.Bool()), p.Styles.Boolean)
+		s.text(p.colorize(fmt.Sprintf("%t", val.Bool()), p.Styles.Boolean))
 
 	case reflect.Ptr:
 		if val.IsNil() {
-			result = p.colorize("nil", p.Styles.Null)
+			s.text(p.colorize("nil", p.Styles.Null))
 		} else {
-			result = p.formatValueWithOptions(val.Elem(), indent, includeStructNames)
+			p.formatValueWithOptions(s, val.Elem(), includeStructNames)
 		}
 
 	case reflect.Interface:
 		if val.IsNil() {
-			result = p.colorize("nil", p.Styles.Null)
+			s.text(p.colorize("nil", p.Styles.Null))
 		} else {
-			result = p.formatValueWithOptions(val.Elem(), indent, includeStructNames)
+			p.formatValueWithOptions(s, val.Elem(), includeStructNames)
 		}
 
 	case reflect.Slice, reflect.Array:
 		// Check for UUID byte slices first
 		if result := p.tryFormatAsUUID(val); result != "" {
-			return result
+			s.text(result)
+			return
 		}
-		result = p.formatSlice(val, indent)
+		if p.formatBytes(s, val) {
+			break
+		}
+		p.formatSlice(s, val)
 
 	case reflect.Map:
-		result = p.formatMap(val, indent)
+		p.formatMap(s, val)
 
 	case reflect.Struct:
-		result = p.formatStruct(val, indent, includeStructNames)
+		p.formatStruct(s, val, includeStructNames)
 
 	case reflect.Chan:
-		result = p.formatChan(val)
+		s.text(p.formatChan(val))
 
 	default:
+		// Values read from unexported fields can't be marshaled
+		if !val.CanInterface() {
+			s.text(fmt.Sprintf("%+v", val))
+			break
+		}
 		// Fallback to JSON for complex types
-		if data, err := json.MarshalIndent(val.Interface(), strings.Repeat("  ", indent), "  "); err == nil {
-			result = string(data)
+		if data, err := json.Marshal(val.Interface()); err == nil {
+			s.text(string(data))
 		} else {
-			result = fmt.Sprintf("%+v", val.Interface())
+			s.text(fmt.Sprintf("%+v", val.Interface()))
 		}
 	}
 
-	return p.appendCyclePointerIfNeeded(result, val)
+	p.appendCyclePointerIfNeeded(s, val)
 }
 
 // formatSlice formats slices and arrays with cycle detection
-func (p *Printer) formatSlice(val reflect.Value, indent int) string {
+func (p *Printer) formatSlice(s *state, val reflect.Value) {
 	if val.Len() == 0 {
-		return "[]"
+		s.text("[]")
+		return
+	}
+
+	if p.formatTable(s, val) {
+		return
 	}
 
 	// Check if slice is too long and should be truncated
@@ -766,167 +981,141 @@ func (p *Printer) formatSlice(val reflect.Value, indent int) string {
 	shouldTruncate := p.MaxSliceLength > 0 && length > p.MaxSliceLength
 
 	if shouldTruncate {
-		return p.formatTruncatedSlice(val, indent, length)
+		p.formatTruncatedSlice(s, val, p.truncateStrategy(s))
+		return
 	}
 
-	// Use the compound formatter for consistent single/multi-line logic
-	formatter := p.newCompoundFormatter("[", "]", "", indent, false, 0)
-
+	c := s.beginCompound("[", "]", false, 0)
 	for i := 0; i < val.Len(); i++ {
-		singleItem := p.formatValue(val.Index(i), 0)       // Single line with 0 indent
-		multiItem := p.formatValue(val.Index(i), indent+1) // Multi line with proper indent
-		formatter.addItem(singleItem, multiItem)
-	}
-
-	return formatter.format()
-}
-
-// formatTruncatedSlice formats a long slice by showing first few, last few, and a summary
-func (p *Printer) formatTruncatedSlice(val reflect.Value, indent int, totalLength int) string {
-	maxLen := p.MaxSliceLength
-	showCount := maxLen / 2 // Show half at beginning, half at end
-	if showCount < 1 {
-		showCount = 1
-	}
-
-	var parts []string
-	nextIndent := indent + 1
-	indentStr := strings.Repeat("  ", nextIndent)
-
-	// Show first elements
-	for i := 0; i < showCount && i < totalLength; i++ {
-		elem := p.formatValue(val.Index(i), nextIndent)
-		parts = append(parts, indentStr+elem)
-	}
-
-	// Add truncation indicator
-	omittedCount := totalLength - (2 * showCount)
-	if omittedCount > 0 {
-		truncMsg := fmt.Sprintf("... %d more elements ...", omittedCount)
-		parts = append(parts, indentStr+p.colorize(truncMsg, p.Styles.Comment))
-	}
-
-	// Show last elements
-	startIdx := totalLength - showCount
-	if startIdx < showCount {
-		startIdx = showCount // Avoid overlap
-	}
-	for i := startIdx; i < totalLength; i++ {
-		elem := p.formatValue(val.Index(i), nextIndent)
-		parts = append(parts, indentStr+elem)
+		c.item()
+		p.formatValue(s, val.Index(i))
 	}
-
-	// Add summary comment
-	summary := fmt.Sprintf("// len() = %d", totalLength)
-	parts = append(parts, indentStr+p.colorize(summary, p.Styles.Comment))
-
-	return fmt.Sprintf("[\n%s\n%s]", strings.Join(parts, ",\n"), strings.Repeat("  ", indent))
+	c.end()
 }
 
 // formatMap formats maps with cycle detection
-func (p *Printer) formatMap(val reflect.Value, indent int) string {
+func (p *Printer) formatMap(s *state, val reflect.Value) {
 	if val.Len() == 0 {
-		return "{}"
+		s.text("{}")
+		return
 	}
 
 	// Sort keys for consistent output
 	keys := val.MapKeys()
-	p.sortMapKeys(keys)
+	p.sortMapKeys(s, keys)
 
-	// Use the compound formatter for consistent single/multi-line logic
-	formatter := p.newCompoundFormatter("{", "}", "", indent, true, p.MaxKeysInline)
+	// Show only the first keys of large maps
+	omitted := 0
+	if p.MaxMapKeys > 0 && len(keys) > p.MaxMapKeys {
+		omitted = len(keys) - p.MaxMapKeys
+		keys = keys[:p.MaxMapKeys]
+	}
+
+	c := s.beginCompound("{", "}", true, p.MaxKeysInline)
 
 	for _, key := range keys {
-		keyStr := p.formatMapKey(key)
+		c.item()
+		p.formatMapKey(s, key)
+		s.text(": ")
+
 		mapValue := val.MapIndex(key)
 
+		if key.Kind() == reflect.String && p.isSecretName(key.String(), mapValue) {
+			p.formatRedacted(s, mapValue)
+			continue
+		}
+
 		// Check if we should omit struct name when key matches struct type
-		var singleValueStr, multiValueStr string
 		if key.Kind() == reflect.String && !p.isSpecialHandledType(mapValue) {
 			// Key matches struct name, format struct without type name
 			actualValue := p.unwrapInterface(mapValue)
 			omitStructName := p.shouldOmitStructName(key.String(), mapValue, nil)
 
-			// Only call formatStruct if the value is actually a struct
+			// Structs are formatted like any other value, so MaxDepth applies
 			if omitStructName && actualValue.Kind() == reflect.Struct {
-				singleValueStr = p.formatStruct(actualValue, 0, !omitStructName)
-				multiValueStr = p.formatStruct(actualValue, indent+1, !omitStructName)
-			} else {
-				singleValueStr = p.formatValue(mapValue, 0)
-				multiValueStr = p.formatValue(mapValue, indent+1)
+				p.formatValueWithOptions(s, actualValue, false)
+				continue
 			}
-		} else {
-			singleValueStr = p.formatValue(mapValue, 0)
-			multiValueStr = p.formatValue(mapValue, indent+1)
 		}
+		p.formatValue(s, mapValue)
+	}
 
-		singleItem := fmt.Sprintf("%s: %s", keyStr, singleValueStr)
-		multiItem := fmt.Sprintf("%s: %s", keyStr, multiValueStr)
-		formatter.addItem(singleItem, multiItem)
+	if omitted > 0 {
+		c.item()
+		s.text(p.colorize("… "+countOf(omitted, "more key"), p.Styles.Comment))
+		p.formatLenComment(s, val.Len())
 	}
 
-	return formatter.format()
+	c.end()
+}
+
+// formatLenComment shows the length of a truncated map as the last line of
+// its compound, when the compound is broken over multiple lines
+func (p *Printer) formatLenComment(s *state, length int) {
+	s.brokenText(",")
+	s.brk("", false)
+	s.brokenText(p.colorize(fmt.Sprintf("// len() = %d", length), p.Styles.Comment))
 }
 
 // formatMapKey formats a map key with cycle detection, treating string keys like struct field names
-func (p *Printer) formatMapKey(key reflect.Value) string {
+func (p *Printer) formatMapKey(s *state, key reflect.Value) {
 	// If the key is a string, format it like a struct field (no quotes, no coloring)
 	if key.Kind() == reflect.String {
 		str := key.String()
 		// Apply string truncation if needed, but no quotes or styling
 		truncatedStr := p.truncateString(str)
-		return p.colorize(truncatedStr, p.Styles.Field)
+		s.text(p.colorize(truncatedStr, p.Styles.Field))
+		return
 	} else if key.Kind() == reflect.Struct {
-		return p.formatStruct(key, 0, false)
+		p.formatStruct(s, key, false)
+		return
 	}
 
 	// For non-string keys, use the regular formatting
-	return p.formatValue(key, 0)
+	p.formatValue(s, key)
 }
 
 // formatStruct formats structs with optional struct name and cycle detection
-func (p *Printer) formatStruct(val reflect.Value, indent int, includeTypeName bool) string {
+func (p *Printer) formatStruct(s *state, val reflect.Value, includeTypeName bool) {
 	typ := val.Type()
-	typName := ""
+	typeName := ""
 	if includeTypeName {
-		typName = typ.Name()
+		typeName = typ.Name()
 	}
 	if val.NumField() == 0 {
-		return fmt.Sprintf("%s{}", typName)
+		s.text(fmt.Sprintf("%s{}", typeName))
+		return
 	}
 
-	// Use compound formatter
-	typeName := ""
-	if includeTypeName {
-		typeName = typ.Name()
-	}
-	formatter := p.newCompoundFormatter("{", "}", typeName, indent, true, p.MaxKeysInline)
+	c := s.beginCompound(typeName+"{", "}", true, p.MaxKeysInline)
 
-	// Process exported fields
-	for i := 0; i < val.NumField(); i++ {
-		field := typ.Field(i)
-		if !field.IsExported() {
+	shown, omitted := 0, 0
+	for _, field := range p.fieldsOf(typ) {
+		fieldVal := val.Field(field.index)
+		if field.omitEmpty && fieldVal.IsZero() {
+			continue
+		}
+		// Show only the first fields of large structs
+		if p.MaxStructFields > 0 && shown == p.MaxStructFields {
+			omitted++
 			continue
 		}
+		shown++
 
-		fieldVal := val.Field(i)
+		c.item()
+		s.text(p.fieldName(field) + ": ")
 
 		// Check if field has concrete type and omit struct name if so
-		var singleFieldStr, multiFieldStr string
-		if !p.isSpecialHandledType(fieldVal) && p.shouldOmitStructName(field.Name, fieldVal, field.Type) {
-			singleFieldStr = p.formatValueWithOptions(fieldVal, 0, false)
-			multiFieldStr = p.formatValueWithOptions(fieldVal, indent+1, false)
-		} else {
-			singleFieldStr = p.formatValue(fieldVal, 0)
-			multiFieldStr = p.formatValue(fieldVal, indent+1)
-		}
+		omitTypeName := !p.isSpecialHandledType(fieldVal) && p.shouldOmitStructName(field.goName, fieldVal, typ.Field(field.index).Type)
+		p.formatFieldValue(s, field, fieldVal, !omitTypeName)
+	}
 
-		singleItem := fmt.Sprintf("%s: %s", field.Name, singleFieldStr)
-		multiItem := fmt.Sprintf("%s: %s", field.Name, multiFieldStr)
-		formatter.addItem(singleItem, multiItem)
+	if omitted > 0 {
+		c.item()
+		s.text(p.colorize("… "+countOf(omitted, "more field"), p.Styles.Comment))
 	}
 
-	return formatter.format()
+	c.end()
 }
 
 func (p *Printer) formatChan(val reflect.Value) string {
@@ -946,17 +1135,17 @@ func (p *Printer) formatChan(val reflect.Value) string {
 }
 
 // sortMapKeys sorts map keys for consistent output
-func (p *Printer) sortMapKeys(keys []reflect.Value) {
+func (p *Printer) sortMapKeys(s *state, keys []reflect.Value) {
 	sort.Slice(keys, func(i, j int) bool {
 		// Convert keys to strings for comparison
-		iStr := p.keyToString(keys[i])
-		jStr := p.keyToString(keys[j])
+		iStr := p.keyToString(s, keys[i])
+		jStr := p.keyToString(s, keys[j])
 		return iStr < jStr
 	})
 }
 
 // keyToString converts a map key to a string for sorting
-func (p *Printer) keyToString(key reflect.Value) string {
+func (p *Printer) keyToString(s *state, key reflect.Value) string {
 	switch key.Kind() {
 	case reflect.String:
 		return key.String()
@@ -970,7 +1159,7 @@ func (p *Printer) keyToString(key reflect.Value) string {
 		return fmt.Sprintf("%t", key.Bool())
 	default:
 		// Fallback to formatted value for other types
-		return p.formatValue(key, 0)
+		return p.sprint(s, key)
 	}
 }
 
@@ -991,17 +1180,18 @@ func (p *Printer) isJSON(str string) (js json.RawMessage, ok bool) {
 	return nil, false
 }
 
-// formatJSON formats a JSON string with proper indentation and colors
-func (p *Printer) formatJSON(jsonStr json.RawMessage, indent int) string {
+// formatJSON formats a JSON string with proper indentation and colors, and
+// reports whether the string could be parsed
+func (p *Printer) formatJSON(s *state, jsonStr json.RawMessage) bool {
 	var parsed any
 	if err := json.Unmarshal(jsonStr, &parsed); err != nil {
-		return ""
+		return false
 	}
 
 	// Use our own formatter to format the parsed JSON with colors
-	return fmt.Sprintf("%s %s",
-		p.colorize("JSON", p.Styles.SpecialType),
-		p.formatValue(reflect.ValueOf(parsed), indent))
+	s.text(p.colorize("JSON", p.Styles.SpecialType) + " ")
+	p.formatValue(s, reflect.ValueOf(parsed))
+	return true
 }
 
 // truncateString truncates a string with center ellipses if it exceeds MaxStringLength
@@ -1031,21 +1221,56 @@ func (p *Printer) truncateString(str string) string {
 	return str[:leftLen] + ellipses + str[len(str)-rightLen:]
 }
 
-// formatTime formats time.Time values using the relative time formatter
+// formatDuration formats time.Duration values using the duration formatter
+func (p *Printer) formatDuration(d time.Duration) string {
+	return p.colorize(NewDurationFormatter().Format(d), p.Styles.Time)
+}
+
+// formatTime formats time.Time values as the TimeMode asks
 func (p *Printer) formatTime(t time.Time) string {
-	// Use the Time function from time.go for humanized relative time
-	formatted := Time(t)
+	if p.TimeLocation != nil {
+		t = t.In(p.TimeLocation)
+	}
+
+	// Use the TimeFormatter from time.go for humanized relative time
+	now := p.now()
+	tf := p.TimeFormatter
+	if tf == nil {
+		tf = NewTimeFormatter()
+	}
+	formatted := tf.WithNow(now).Format(t)
 	if t.IsZero() {
 		// Use special type style for <zero> like other special markers
 		return p.colorize(formatted, p.Styles.SpecialType)
 	}
-	if time.Until(t).Abs() > 30*time.Minute {
+
+	layout := p.TimeLayout
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+
+	switch p.TimeMode {
+	case TimeAbsolute:
+		return p.colorize(t.Format(layout), p.Styles.Time)
+	case TimeBoth:
+		return fmt.Sprintf("%s %s", p.colorize(formatted, p.Styles.Time), p.colorize("("+t.Format(layout)+")", p.Styles.Comment))
+	}
+
+	if t.Sub(now).Abs() > 30*time.Minute {
 		return fmt.Sprintf("%s %s", p.colorize(formatted, p.Styles.Time), p.colorize(t.Format(time.Kitchen), p.Styles.Comment))
 	}
 
 	return p.colorize(formatted, p.Styles.Time)
 }
 
+// now returns the current time from the Clock
+func (p *Printer) now() time.Time {
+	if p.Clock != nil {
+		return p.Clock()
+	}
+	return time.Now()
+}
+
 // canFormCycles returns true if the given value can potentially form cycles
 func (p *Printer) canFormCycles(val reflect.Value) bool {
 	switch val.Kind() {
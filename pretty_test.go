@@ -4,6 +4,7 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -225,6 +226,78 @@ func TestTime(t *testing.T) {
 	}
 }
 
+func TestClock(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 4, 5, 0, time.UTC)
+	printer := New().WithColorMode(ColorNever).WithClock(func() time.Time { return now })
+
+	tests := []struct {
+		input    time.Time
+		expected string
+	}{
+		{now.Add(-5 * time.Minute), "5 minutes ago"},
+		{now.Add(-2 * time.Hour), "2 hours ago 1:04PM"},
+		{now.Add(3 * 24 * time.Hour), "in 3 days 3:04PM"},
+	}
+
+	for _, tt := range tests {
+		if result := printer.Print(tt.input); result != tt.expected {
+			t.Errorf("Print(%v) = %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestTimeModes(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 4, 5, 0, time.UTC)
+	printer := New().WithColorMode(ColorNever).WithClock(func() time.Time { return now })
+	input := now.Add(-2 * time.Hour)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name     string
+		printer  *Printer
+		expected string
+	}{
+		{
+			name:     "relative",
+			printer:  printer.WithTimeMode(TimeRelative),
+			expected: "2 hours ago 1:04PM",
+		},
+		{
+			name:     "absolute",
+			printer:  printer.WithTimeMode(TimeAbsolute),
+			expected: "2026-10-16 13:04:05 UTC",
+		},
+		{
+			name:     "absolute with layout",
+			printer:  printer.WithTimeMode(TimeAbsolute).WithTimeLayout(time.RFC3339),
+			expected: "2026-10-16T13:04:05Z",
+		},
+		{
+			name:     "both",
+			printer:  printer.WithTimeMode(TimeBoth),
+			expected: "2 hours ago (2026-10-16 13:04:05 UTC)",
+		},
+		{
+			name:     "location",
+			printer:  printer.WithTimeMode(TimeBoth).WithTimeLocation(tokyo),
+			expected: "2 hours ago (2026-10-16 22:04:05 JST)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.printer.Print(input); result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+
+	future := printer.WithTimeFormatter(NewTimeFormatter().WithFutureFormat("%s from now")).Print(now.Add(5 * time.Minute))
+	if future != "5 minutes from now" {
+		t.Errorf("Print() with a time formatter = %q, want %q", future, "5 minutes from now")
+	}
+}
+
 func TestPrinterWithCustomWidth(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -539,6 +612,63 @@ func TestWithMaxSliceLength(t *testing.T) {
 	}
 }
 
+func TestMapTruncation(t *testing.T) {
+	large := make(map[string]int, 50000)
+	for i := 0; i < 50000; i++ {
+		large[strings.Repeat("k", 1+i/26)+string(rune('a'+i%26))] = i
+	}
+
+	tests := []struct {
+		name     string
+		width    int
+		input    map[string]int
+		expected string
+	}{
+		{
+			name:     "under the limit",
+			width:    100,
+			input:    map[string]int{"a": 1, "b": 2},
+			expected: "{ a: 1, b: 2 }",
+		},
+		{
+			name:     "single-line",
+			width:    100,
+			input:    map[string]int{"a": 1, "b": 2, "c": 3, "d": 4},
+			expected: "{ a: 1, b: 2, c: 3, … 1 more key }",
+		},
+		{
+			name:     "multi-line",
+			width:    20,
+			input:    large,
+			expected: "{\n  ka: 0,\n  kb: 1,\n  kc: 2,\n  … 49,997 more keys,\n  // len() = 50000\n}",
+		},
+	}
+
+	printer := New().WithColorMode(ColorNever).WithMaxMapKeys(3)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := printer.WithMaxWidth(tt.width).Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStructFieldTruncation(t *testing.T) {
+	printer := New().WithColorMode(ColorNever).WithMaxStructFields(2)
+
+	result := printer.Print(TestStruct{Name: "Alice", Age: 30})
+	if expected := `TestStruct{ Name: "Alice", Age: 30, … 1 more field }`; result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+
+	result = printer.WithMaxWidth(20).Print(TestStruct{Name: "Alice", Age: 30})
+	if expected := "TestStruct{\n  Name: \"Alice\",\n  Age: 30,\n  … 1 more field\n}"; result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
 func TestStringTruncation(t *testing.T) {
 	printer := New().WithColorMode(ColorNever).WithMaxStringLength(20)
 
@@ -864,6 +994,33 @@ func TestCycleDetection(t *testing.T) {
 	})
 }
 
+func TestSequentialIDs(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+
+	a := &Node{Value: 1}
+	b := &Node{Value: 2, Next: a}
+	a.Next = b
+
+	printer := New().WithColorMode(ColorNever).WithSequentialIDs(true)
+	expected := "Node{ Value: 1, Next: { Value: 2, Next: →#1 } }#1"
+	for i := 0; i < 3; i++ {
+		if result := printer.Print(a); result != expected {
+			t.Errorf("Print() = %q, want %q", result, expected)
+		}
+	}
+
+	// Each value in a cycle gets the next ID
+	c := &Node{Value: 3}
+	c.Next = c
+	expected = "[Node{ Value: 1, Next: { Value: 2, Next: →#1 } }#1, Node{ Value: 3, Next: →#2 }#2]"
+	if result := printer.Print([]*Node{a, c}); result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
 func TestUUIDDetection(t *testing.T) {
 	t.Run("valid UUID v4 byte slice", func(t *testing.T) {
 		// Create a valid UUID v4: 550e8400-e29b-41d4-a716-446655440000
@@ -1228,3 +1385,143 @@ func TestConcreteTypeStructNameOmission(t *testing.T) {
 func hasCycle(result string) bool {
 	return strings.Contains(result, "→#")
 }
+
+// failingWriter accepts limit bytes and then fails every write
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if len(b) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, io.ErrShortWrite
+	}
+	w.limit -= len(b)
+	return len(b), nil
+}
+
+func TestFprint(t *testing.T) {
+	data := NestedStruct{
+		User:   TestStruct{Name: "Alice", Age: 25, Email: "alice@example.com"},
+		Active: true,
+		Tags:   []string{"admin", "user"},
+	}
+
+	t.Run("matches Print", func(t *testing.T) {
+		printer := New().WithColorMode(ColorNever).WithMaxWidth(30)
+
+		var buf strings.Builder
+		n, err := printer.Fprint(&buf, data)
+		if err != nil {
+			t.Fatalf("Fprint() error = %v", err)
+		}
+
+		expected := printer.Print(data)
+		if buf.String() != expected {
+			t.Errorf("Fprint() wrote %q, want %q", buf.String(), expected)
+		}
+		if n != len(expected) {
+			t.Errorf("Fprint() n = %d, want %d", n, len(expected))
+		}
+	})
+
+	t.Run("Fprintln adds a newline", func(t *testing.T) {
+		var buf strings.Builder
+		n, err := New().WithColorMode(ColorNever).Fprintln(&buf, []int{1, 2, 3})
+		if err != nil {
+			t.Fatalf("Fprintln() error = %v", err)
+		}
+		if buf.String() != "[1, 2, 3]\n" {
+			t.Errorf("Fprintln() wrote %q, want %q", buf.String(), "[1, 2, 3]\n")
+		}
+		if n != buf.Len() {
+			t.Errorf("Fprintln() n = %d, want %d", n, buf.Len())
+		}
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		var buf strings.Builder
+		Fprint(&buf, nil)
+		if buf.String() != "nil" {
+			t.Errorf("Fprint(nil) wrote %q, want %q", buf.String(), "nil")
+		}
+	})
+
+	t.Run("ColorAuto checks the writer", func(t *testing.T) {
+		// Not a terminal, so no colors even if stdout is one
+		var buf strings.Builder
+		New().WithColorMode(ColorAuto).Fprint(&buf, data)
+		if strings.Contains(buf.String(), "\x1b[") {
+			t.Errorf("Expected no colors when writing to a buffer, got %q", buf.String())
+		}
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		w := &failingWriter{limit: 10}
+		n, err := New().WithColorMode(ColorNever).Fprint(w, data)
+		if err != io.ErrShortWrite {
+			t.Errorf("Fprint() error = %v, want %v", err, io.ErrShortWrite)
+		}
+		if n != 10 {
+			t.Errorf("Fprint() n = %d, want 10", n)
+		}
+	})
+
+	t.Run("margin", func(t *testing.T) {
+		printer := New().WithColorMode(ColorNever).WithMargin(1)
+
+		var buf strings.Builder
+		printer.Fprint(&buf, []int{1, 2, 3})
+		if buf.String() != printer.Print([]int{1, 2, 3}) {
+			t.Errorf("Fprint() wrote %q, want %q", buf.String(), printer.Print([]int{1, 2, 3}))
+		}
+	})
+}
+
+// TestConcurrentPrint is meant to be run with -race to catch any state shared
+// between calls
+func TestConcurrentPrint(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+
+	// Cycles exercise the traversal state the most
+	node1 := &Node{Value: 1}
+	node2 := &Node{Value: 2, Next: node1}
+	node1.Next = node2
+
+	data := map[string]interface{}{
+		"nested": NestedStruct{
+			User:   TestStruct{Name: "Alice", Age: 25, Email: "alice@example.com"},
+			Active: true,
+			Tags:   []string{"admin", "user"},
+		},
+		"cycle": node1,
+		"json":  `{"debug":true,"timeout":30}`,
+	}
+
+	printer := New().WithColorMode(ColorNever).WithMaxWidth(40)
+	expected := printer.Print(data)
+	expectedDefault := Print(data)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if result := printer.Print(data); result != expected {
+					t.Errorf("concurrent Print() = %q, want %q", result, expected)
+					return
+				}
+				if result := Print(data); result != expectedDefault {
+					t.Errorf("concurrent pretty.Print() = %q, want %q", result, expectedDefault)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,99 @@
+// Package prettytest provides test assertions that report failures as
+// pretty printed structural diffs.
+//
+// Basic usage:
+//
+//	func TestUser(t *testing.T) {
+//		prettytest.Equal(t, want, got)
+//	}
+//
+// With options:
+//
+//	prettytest.Equal(t, want, got,
+//		prettytest.IgnoreFields("ID", "Items[*].CreatedAt"),
+//		prettytest.FloatTolerance(1e-9),
+//		prettytest.NilEqualsEmpty(),
+//	)
+package prettytest
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jogly/pretty"
+)
+
+// Option configures how Equal and NotEqual compare values
+type Option func(*pretty.DiffOptions)
+
+// IgnoreFields ignores the values at paths when comparing, as described by
+// pretty.DiffOptions.IgnorePaths
+func IgnoreFields(paths ...string) Option {
+	return func(opts *pretty.DiffOptions) {
+		opts.IgnorePaths = append(opts.IgnorePaths, paths...)
+	}
+}
+
+// FloatTolerance compares floats that differ by at most tolerance as equal
+func FloatTolerance(tolerance float64) Option {
+	return func(opts *pretty.DiffOptions) {
+		opts.FloatTolerance = tolerance
+	}
+}
+
+// NilEqualsEmpty compares nil slices and maps as equal to empty ones
+func NilEqualsEmpty() Option {
+	return func(opts *pretty.DiffOptions) {
+		opts.NilEqualsEmpty = true
+	}
+}
+
+// Equal reports whether want and got are equal, and fails the test with a
+// diff of the two if they are not
+func Equal(t testing.TB, want, got any, opts ...Option) bool {
+	t.Helper()
+
+	diff := printer(opts).Diff(want, got)
+	if diff == "" {
+		return true
+	}
+	t.Errorf("values are not equal (- want, + got):\n%s", diff)
+	return false
+}
+
+// NotEqual reports whether want and got differ, and fails the test with the
+// value if they are equal
+func NotEqual(t testing.TB, want, got any, opts ...Option) bool {
+	t.Helper()
+
+	p := printer(opts)
+	if p.Diff(want, got) != "" {
+		return true
+	}
+	t.Errorf("values are equal:\n%s", p.Print(got))
+	return false
+}
+
+// printer returns the printer used to compare values with opts
+func printer(opts []Option) *pretty.Printer {
+	var diffOpts pretty.DiffOptions
+	for _, opt := range opts {
+		opt(&diffOpts)
+	}
+	// Times are shown exactly, as relative times hide their differences
+	return pretty.New().
+		WithColorMode(colorMode()).
+		WithDiffOptions(diffOpts).
+		WithTimeMode(pretty.TimeAbsolute).
+		WithTimeLayout(time.RFC3339Nano)
+}
+
+// colorMode only uses colors when the test output goes to a terminal
+func colorMode() pretty.ColorMode {
+	fileInfo, err := os.Stdout.Stat()
+	if err == nil && fileInfo.Mode()&os.ModeCharDevice != 0 {
+		return pretty.ColorAlways
+	}
+	return pretty.ColorNever
+}
@@ -0,0 +1,127 @@
+package prettytest
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recorder records the failures of an assertion instead of failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type point struct {
+	ID   int
+	X, Y float64
+	Tags []string
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     any
+		got      any
+		opts     []Option
+		ok       bool
+		contains string
+	}{
+		{
+			name: "equal",
+			want: point{ID: 1, X: 1, Y: 2},
+			got:  point{ID: 1, X: 1, Y: 2},
+			ok:   true,
+		},
+		{
+			name:     "not equal",
+			want:     point{ID: 1, X: 1, Y: 2},
+			got:      point{ID: 1, X: 1, Y: 3},
+			ok:       false,
+			contains: "values are not equal (- want, + got):\npoint{\n  … 2 unchanged fields,\n  - Y: 2,\n  + Y: 3,\n  … 1 unchanged field\n}",
+		},
+		{
+			name:     "times a few seconds apart",
+			want:     time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			got:      time.Date(2020, 1, 1, 10, 0, 30, 0, time.UTC),
+			ok:       false,
+			contains: "- 2020-01-01T10:00:00Z\n+ 2020-01-01T10:00:30Z",
+		},
+		{
+			name: "the same instant",
+			want: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			got:  time.Date(2020, 1, 1, 11, 0, 0, 0, time.FixedZone("CET", 60*60)),
+			ok:   true,
+		},
+		{
+			name: "structs without shown fields",
+			want: big.NewInt(1),
+			got:  big.NewInt(2),
+			ok:   false,
+		},
+		{
+			name: "equal structs without shown fields",
+			want: big.NewInt(1),
+			got:  big.NewInt(1),
+			ok:   true,
+		},
+		{
+			name: "ignored fields",
+			want: []point{{ID: 1}, {ID: 2}},
+			got:  []point{{ID: 3}, {ID: 4}},
+			opts: []Option{IgnoreFields("[*].ID")},
+			ok:   true,
+		},
+		{
+			name: "float tolerance",
+			want: point{X: 0.3},
+			got:  point{X: 0.1 + 0.2},
+			opts: []Option{FloatTolerance(1e-9)},
+			ok:   true,
+		},
+		{
+			name: "nil equals empty",
+			want: point{},
+			got:  point{Tags: []string{}},
+			opts: []Option{NilEqualsEmpty()},
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if ok := Equal(r, tt.want, tt.got, tt.opts...); ok != tt.ok {
+				t.Errorf("Equal() = %v, want %v", ok, tt.ok)
+			}
+			if tt.ok != (len(r.errors) == 0) {
+				t.Errorf("Equal() reported %q", r.errors)
+			}
+			if tt.contains != "" && (len(r.errors) == 0 || !strings.Contains(r.errors[0], tt.contains)) {
+				t.Errorf("Equal() reported %q, want %q", r.errors, tt.contains)
+			}
+		})
+	}
+}
+
+func TestNotEqual(t *testing.T) {
+	r := &recorder{TB: t}
+	if !NotEqual(r, point{ID: 1}, point{ID: 2}) || len(r.errors) != 0 {
+		t.Errorf("NotEqual() of different values reported %q", r.errors)
+	}
+
+	if NotEqual(r, point{ID: 1}, point{ID: 1}) {
+		t.Error("NotEqual() of equal values = true, want false")
+	}
+	if len(r.errors) != 1 || !strings.HasPrefix(r.errors[0], "values are equal:\npoint{") {
+		t.Errorf("NotEqual() reported %q", r.errors)
+	}
+}
@@ -0,0 +1,147 @@
+package prettytest
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jogly/pretty"
+)
+
+// UpdateEnv is the environment variable that rewrites snapshots when set to
+// a true value, like the -prettytest.update flag
+const UpdateEnv = "PRETTYTEST_UPDATE"
+
+// update is namespaced so that test packages can define their own -update
+// flag, which is also honored when they do
+var update = flag.Bool("prettytest.update", false, "rewrite prettytest snapshots with the current values")
+
+// snapshotWidth is the fixed width snapshots are laid out in
+const snapshotWidth = 100
+
+// Snapshot compares the pretty printed v to the snapshot of the test in
+// testdata/<TestName>.snap, and fails the test with a line diff of the two
+// if they differ. Run the tests with -prettytest.update, or with
+// PRETTYTEST_UPDATE=1, to write the current value to the snapshot instead.
+// Packages that define their own boolean -update flag can use it too.
+//
+// Snapshots are printed without colors at a fixed width, with times shown
+// in UTC and values in cycles numbered in the order they are reached, so
+// they are the same on every run and machine. Each test has a single
+// snapshot.
+func Snapshot(t testing.TB, v any) bool {
+	t.Helper()
+
+	path := filepath.Join("testdata", snapshotName(t.Name())+".snap")
+	got := snapshotPrint(v) + "\n"
+
+	if shouldUpdate() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating snapshot directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("writing snapshot: %v", err)
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("no snapshot at %s, run the test with -prettytest.update or %s=1 to create it", path, UpdateEnv)
+		return false
+	} else if err != nil {
+		t.Fatalf("reading snapshot: %v", err)
+	}
+
+	if string(want) == got {
+		return true
+	}
+	t.Errorf("value does not match snapshot %s (- snapshot, + got):\n%s", path, lineDiff(string(want), got))
+	return false
+}
+
+// shouldUpdate reports whether snapshots are rewritten
+func shouldUpdate() bool {
+	if *update {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if ok, _ := strconv.ParseBool(f.Value.String()); ok {
+			return true
+		}
+	}
+	ok, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return ok
+}
+
+// snapshotName returns the name of a test as a file name
+func snapshotName(testName string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, testName)
+}
+
+// snapshotPrint prints v as it is stored in a snapshot
+func snapshotPrint(v any) string {
+	p := pretty.New().
+		WithColorMode(pretty.ColorNever).
+		WithMaxWidth(snapshotWidth).
+		WithSequentialIDs(true)
+
+	// Relative times depend on when the test runs
+	pretty.RegisterFormatterFor(p, func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339Nano)
+	})
+
+	return p.Print(v)
+}
+
+// lineDiff returns a diff of the lines of want and got, marking the lines
+// only in want with - and the lines only in got with +
+func lineDiff(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			sb.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+package prettytest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ownUpdate is the -update flag of a package with golden files of its own,
+// which must not clash with prettytest
+var ownUpdate = flag.Bool("update", false, "rewrite golden files")
+
+type node struct {
+	Name    string
+	Created time.Time
+	Next    *node
+}
+
+func cycle() *node {
+	a := &node{Name: "a", Created: time.Date(2026, 10, 16, 13, 4, 5, 0, time.FixedZone("PDT", -7*60*60))}
+	a.Next = &node{Name: "b", Next: a}
+	return a
+}
+
+func TestSnapshot(t *testing.T) {
+	Snapshot(t, cycle())
+}
+
+func TestSnapshotMismatch(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	r := &recorder{TB: t}
+	if Snapshot(r, []string{"a"}) || len(r.errors) != 1 || !strings.Contains(r.errors[0], "no snapshot at testdata/TestSnapshotMismatch.snap") {
+		t.Fatalf("Snapshot() without a file reported %q", r.errors)
+	}
+
+	t.Setenv(UpdateEnv, "1")
+	if !Snapshot(r, []string{"a", "b", "c"}) {
+		t.Fatalf("Snapshot() failed to update: %q", r.errors)
+	}
+
+	t.Setenv(UpdateEnv, "")
+	r.errors = nil
+	if !Snapshot(r, []string{"a", "b", "c"}) || len(r.errors) != 0 {
+		t.Fatalf("Snapshot() of the same value reported %q", r.errors)
+	}
+
+	long := func(s ...string) []string {
+		for i := range s {
+			s[i] = strings.Repeat(s[i], 40)
+		}
+		return s
+	}
+	t.Setenv(UpdateEnv, "true")
+	Snapshot(r, long("a", "b", "c"))
+	t.Setenv(UpdateEnv, "")
+
+	if Snapshot(r, long("a", "x", "c")) {
+		t.Fatal("Snapshot() of a different value = true, want false")
+	}
+	expected := "  [\n" +
+		"    \"" + strings.Repeat("a", 40) + "\",\n" +
+		"-   \"" + strings.Repeat("b", 40) + "\",\n" +
+		"+   \"" + strings.Repeat("x", 40) + "\",\n" +
+		"    \"" + strings.Repeat("c", 40) + "\"\n" +
+		"  ]\n"
+	if len(r.errors) != 1 || !strings.HasSuffix(r.errors[0], expected) {
+		t.Errorf("Snapshot() reported %q, want a diff ending in %q", r.errors, expected)
+	}
+
+	if _, err := os.Stat(filepath.Join("testdata", "TestSnapshotMismatch.snap")); err != nil {
+		t.Errorf("snapshot was not written: %v", err)
+	}
+}
+
+func TestSnapshotOwnUpdateFlag(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	flag.Set("update", "true")
+	defer flag.Set("update", "false")
+
+	r := &recorder{TB: t}
+	if !Snapshot(r, []string{"a"}) || len(r.errors) != 0 || !*ownUpdate {
+		t.Fatalf("Snapshot() with -update reported %q", r.errors)
+	}
+	if _, err := os.Stat(filepath.Join("testdata", "TestSnapshotOwnUpdateFlag.snap")); err != nil {
+		t.Errorf("snapshot was not written: %v", err)
+	}
+}
+
+func TestSnapshotName(t *testing.T) {
+	if got := snapshotName("TestA/sub_test:1"); got != "TestA_sub_test_1" {
+		t.Errorf("snapshotName() = %q", got)
+	}
+}
@@ -0,0 +1,156 @@
+package pretty
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// DefaultSecretPattern matches the names of struct fields and string map keys
+// that usually hold secrets
+var DefaultSecretPattern = regexp.MustCompile(`(?i)password|passwd|secret|token|apikey|api_key|authorization|credential|private_?key`)
+
+var (
+	jwtPattern          = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	awsAccessKeyPattern = regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA)[A-Z0-9]{16}\b`)
+	bearerTokenPattern  = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`)
+)
+
+// DetectJWT reports whether s contains a JSON Web Token
+func DetectJWT(s string) bool {
+	return jwtPattern.MatchString(s)
+}
+
+// DetectAWSAccessKey reports whether s contains an AWS access key ID
+func DetectAWSAccessKey(s string) bool {
+	return awsAccessKeyPattern.MatchString(s)
+}
+
+// DetectBearerToken reports whether s contains a bearer token, as found in
+// Authorization headers
+func DetectBearerToken(s string) bool {
+	return bearerTokenPattern.MatchString(s)
+}
+
+// Redactor decides which values are secrets and how they are shown. Fields
+// tagged with `pretty:"secret"` are always redacted, with or without one.
+type Redactor struct {
+	// FieldPattern matches the names of struct fields and string map keys
+	// whose values are secrets. If nil, no names are matched.
+	FieldPattern *regexp.Regexp
+	// Detectors report whether a string is a secret wherever it appears,
+	// including in the JSON strings that are expanded
+	Detectors []func(string) bool
+	// HashKey, if set, shows secrets as a short HMAC-SHA256 of their value
+	// instead of their length, so equal secrets can be told apart without
+	// being revealed
+	HashKey []byte
+}
+
+// NewRedactor creates a Redactor that matches DefaultSecretPattern, JWTs,
+// AWS access keys and bearer tokens
+func NewRedactor() *Redactor {
+	return &Redactor{
+		FieldPattern: DefaultSecretPattern,
+		Detectors:    []func(string) bool{DetectJWT, DetectAWSAccessKey, DetectBearerToken},
+	}
+}
+
+// WithRedactor creates a new Printer that redacts secrets with r. If r is
+// nil, only fields tagged with `pretty:"secret"` are redacted.
+func (p *Printer) WithRedactor(r *Redactor) *Printer {
+	newP := p.copyPrinter()
+	newP.Redactor = r
+	return newP
+}
+
+// NewForLogs creates a Printer suited to log output, without colors and with
+// secrets redacted by NewRedactor
+func NewForLogs() *Printer {
+	p := New()
+	p.ColorMode = ColorNever
+	p.Redactor = NewRedactor()
+	return p
+}
+
+// isSecretName reports whether val, the value of a field or string map key
+// named name, is a secret. Booleans can't hold secrets, so flags such as
+// PasswordSet are shown as they are.
+func (p *Printer) isSecretName(name string, val reflect.Value) bool {
+	return p.Redactor != nil && p.Redactor.FieldPattern != nil && p.Redactor.FieldPattern.MatchString(name) &&
+		canHoldSecret(val)
+}
+
+// isSecretField reports whether val, the value of a struct field, is a secret
+func (p *Printer) isSecretField(field fieldInfo, val reflect.Value) bool {
+	return field.secret || p.isSecretName(field.goName, val) || p.isSecretName(field.name, val)
+}
+
+// canHoldSecret reports whether val is anything but a boolean
+func canHoldSecret(val reflect.Value) bool {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return true
+		}
+		val = val.Elem()
+	}
+	return val.Kind() != reflect.Bool
+}
+
+// isSecretString reports whether str is a secret by itself
+func (p *Printer) isSecretString(str string) bool {
+	if p.Redactor == nil {
+		return false
+	}
+	for _, detect := range p.Redactor.Detectors {
+		if detect(str) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRedacted formats a secret value as <redacted len=N>, or as
+// <redacted hmac=…> when the Redactor has a HashKey. Nil values are not
+// secrets, and are shown as nil.
+func (p *Printer) formatRedacted(s *state, val reflect.Value) {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			s.text(p.colorize("nil", p.Styles.Null))
+			return
+		}
+		val = val.Elem()
+	}
+
+	var data []byte
+	hasLen := true
+	switch {
+	case val.Kind() == reflect.String:
+		data = []byte(val.String())
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8:
+		data = val.Bytes()
+	default:
+		hasLen = false
+		if val.CanInterface() {
+			data = []byte(fmt.Sprint(val.Interface()))
+		}
+	}
+	s.text(p.redacted(data, hasLen))
+}
+
+// redacted returns the placeholder of secret data, with its length if
+// hasLen, or its HMAC when the Redactor has a HashKey
+func (p *Printer) redacted(data []byte, hasLen bool) string {
+	text := "<redacted>"
+	if p.Redactor != nil && len(p.Redactor.HashKey) > 0 && data != nil {
+		mac := hmac.New(sha256.New, p.Redactor.HashKey)
+		mac.Write(data)
+		text = fmt.Sprintf("<redacted hmac=%s>", hex.EncodeToString(mac.Sum(nil)[:4]))
+	} else if hasLen {
+		text = fmt.Sprintf("<redacted len=%d>", len(data))
+	}
+	return p.colorize(text, p.Styles.Comment)
+}
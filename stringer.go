@@ -0,0 +1,107 @@
+package pretty
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// StringerMode controls when values are formatted with their String or
+// MarshalText methods. Errors are always formatted with their messages and
+// the errors they wrap.
+type StringerMode int
+
+const (
+	// StringerNever never calls the methods, and always shows values as they are
+	StringerNever StringerMode = iota
+	// StringerLeaf calls the methods for every value except structs with
+	// exported fields, which keep showing their fields
+	StringerLeaf
+	// StringerAlways calls the methods for every value that implements one
+	StringerAlways
+)
+
+// WithStringerMode creates a new Printer with the specified stringer mode
+func (p *Printer) WithStringerMode(mode StringerMode) *Printer {
+	newP := p.copyPrinter()
+	newP.StringerMode = mode
+	return newP
+}
+
+var (
+	errorType         = reflect.TypeFor[error]()
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// isStringer reports whether val is formatted with its String or MarshalText
+// method under the StringerMode
+func (p *Printer) isStringer(val reflect.Value) bool {
+	if p.StringerMode == StringerNever || !hasMethods(val) {
+		return false
+	}
+
+	if p.StringerMode == StringerLeaf && hasExportedFields(val) {
+		return false
+	}
+
+	typ := val.Type()
+	return typ.Implements(stringerType) || typ.Implements(textMarshalerType)
+}
+
+// stringFor returns the result of the String or MarshalText method of val,
+// in that order, when the StringerMode allows it. Panics in the methods,
+// such as from nil receivers, are recovered and reported as not ok.
+func (p *Printer) stringFor(val reflect.Value) (str string, ok bool) {
+	if !p.isStringer(val) {
+		return "", false
+	}
+
+	defer func() {
+		if recover() != nil {
+			str, ok = "", false
+		}
+	}()
+
+	switch v := val.Interface().(type) {
+	case fmt.Stringer:
+		return v.String(), true
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return "", false
+		}
+		return string(text), true
+	}
+
+	return "", false
+}
+
+// formatStringer formats the result of a value's String like method next to
+// the name of its type, redacting it if it is a secret
+func (p *Printer) formatStringer(typ reflect.Type, str string) string {
+	text := p.colorize(p.truncateString(str), p.Styles.String)
+	if p.isSecretString(str) {
+		text = p.redacted([]byte(str), true)
+	}
+	return p.colorize(typ.String(), p.Styles.SpecialType) + "(" + text + ")"
+}
+
+// hasExportedFields reports whether val is a struct, or a pointer to one, with
+// any exported fields
+func hasExportedFields(val reflect.Value) bool {
+	typ := val.Type()
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
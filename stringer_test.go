@@ -0,0 +1,131 @@
+package pretty
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Level implements encoding.TextMarshaler only
+type Level int
+
+func (l Level) MarshalText() ([]byte, error) {
+	if l > 2 {
+		return nil, errors.New("unknown level")
+	}
+	return []byte([]string{"debug", "info", "warn"}[l]), nil
+}
+
+// Node dereferences its receiver without checking for nil
+type Node struct {
+	Name string
+}
+
+func (n *Node) String() string {
+	return "node " + n.Name
+}
+
+// Wrapper holds a nil Node that panics when formatted
+type Wrapper struct {
+	node *Node
+}
+
+func (w Wrapper) String() string {
+	return w.node.String()
+}
+
+func TestStringerMode(t *testing.T) {
+	link, _ := url.Parse("https://example.com/path")
+
+	tests := []struct {
+		name     string
+		mode     StringerMode
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "never is the default",
+			mode:     StringerNever,
+			input:    Level(1),
+			expected: "1",
+		},
+		{
+			name:     "durations are formatted as durations",
+			mode:     StringerLeaf,
+			input:    90 * time.Minute,
+			expected: "1h30m",
+		},
+		{
+			name:     "ip",
+			mode:     StringerLeaf,
+			input:    net.ParseIP("192.168.0.1"),
+			expected: "net.IP(192.168.0.1)",
+		},
+		{
+			name:     "error",
+			mode:     StringerLeaf,
+			input:    errors.New("boom"),
+			expected: `*errors.errorString("boom")`,
+		},
+		{
+			name:     "text marshaler",
+			mode:     StringerLeaf,
+			input:    Level(1),
+			expected: "pretty.Level(info)",
+		},
+		{
+			name:     "text marshaler error",
+			mode:     StringerLeaf,
+			input:    Level(7),
+			expected: "7",
+		},
+		{
+			name:     "leaf keeps exported fields",
+			mode:     StringerLeaf,
+			input:    &Node{Name: "a"},
+			expected: `Node{ Name: "a" }`,
+		},
+		{
+			name:     "always",
+			mode:     StringerAlways,
+			input:    &Node{Name: "a"},
+			expected: "*pretty.Node(node a)",
+		},
+		{
+			name:     "always with struct",
+			mode:     StringerAlways,
+			input:    link,
+			expected: "*url.URL(https://example.com/path)",
+		},
+		{
+			name:     "nil pointer",
+			mode:     StringerAlways,
+			input:    (*Node)(nil),
+			expected: "nil",
+		},
+		{
+			name:     "panic is recovered",
+			mode:     StringerAlways,
+			input:    Wrapper{},
+			expected: "Wrapper{}",
+		},
+		{
+			name:     "in a struct",
+			mode:     StringerLeaf,
+			input:    struct{ Addr net.IP }{Addr: net.ParseIP("10.0.0.1")},
+			expected: "{ Addr: net.IP(10.0.0.1) }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer := New().WithColorMode(ColorNever).WithStringerMode(tt.mode)
+			result := printer.Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package pretty
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TableMode controls when slices of structs and of maps with string keys are
+// laid out as tables, with a column for each field or key
+type TableMode int
+
+const (
+	// TableNever never lays out slices as tables
+	TableNever TableMode = iota
+	// TableAuto lays out slices with at least two rows as tables when they
+	// don't fit on a single line
+	TableAuto
+	// TableAlways lays out every slice of structs or maps as a table
+	TableAlways
+)
+
+// tableColumnGap separates the columns of a table
+const tableColumnGap = "  "
+
+// WithTableMode creates a new Printer with the specified table mode
+func (p *Printer) WithTableMode(mode TableMode) *Printer {
+	newP := p.copyPrinter()
+	newP.TableMode = mode
+	return newP
+}
+
+// tableColumn is a column of a table, holding the value of a struct field or
+// map key in each row
+type tableColumn struct {
+	header string
+	field  fieldInfo     // the field of the column, for structs
+	key    reflect.Value // the key of the column, for maps
+	cells  []string
+	width  int
+}
+
+// formatTable lays out a slice of structs or maps with string keys as a
+// table, and reports whether it did. Tables need line breaks, so slices in
+// inline regions, such as the cells of other tables, are left as lists.
+func (p *Printer) formatTable(s *state, val reflect.Value) bool {
+	if p.TableMode == TableNever || val.Len() == 0 || (p.TableMode == TableAuto && val.Len() < 2) || s.inline > 0 {
+		return false
+	}
+
+	elemType := val.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	switch {
+	case elemType.Kind() == reflect.Struct && elemType != timeType:
+		if len(p.fieldsOf(elemType)) == 0 {
+			return false
+		}
+	case elemType.Kind() == reflect.Map && elemType.Key().Kind() == reflect.String:
+	default:
+		return false
+	}
+
+	// Rows past MaxSliceLength are left out like the elements of other slices
+	rows := p.truncatedIndices(p.truncateStrategy(s), val.Len())
+
+	var columns []*tableColumn
+	if elemType.Kind() == reflect.Struct {
+		for _, field := range p.fieldsOf(elemType) {
+			columns = append(columns, &tableColumn{header: field.name, field: field})
+		}
+	} else {
+		columns = p.mapColumns(val, rows)
+	}
+
+	for _, i := range rows {
+		row := val.Index(i)
+		for row.Kind() == reflect.Ptr || row.Kind() == reflect.Interface {
+			if row.IsNil() {
+				break
+			}
+			row = row.Elem()
+		}
+		for j, col := range columns {
+			col.cells = append(col.cells, p.tableCell(s, row, col, j == 0))
+		}
+	}
+
+	if p.TableMode == TableAuto && p.fitsOnOneLine(columns, len(rows), elemType) {
+		return false
+	}
+
+	p.layoutTable(s, val.Len(), rows, columns)
+	return true
+}
+
+// mapColumns returns a column for each key in the maps of the rows, sorted
+func (p *Printer) mapColumns(val reflect.Value, rows []int) []*tableColumn {
+	seen := make(map[string]bool)
+	var columns []*tableColumn
+	for _, i := range rows {
+		row := p.unwrapInterface(val.Index(i))
+		if row.Kind() == reflect.Ptr && !row.IsNil() {
+			row = row.Elem()
+		}
+		if row.Kind() != reflect.Map {
+			continue
+		}
+		for _, key := range row.MapKeys() {
+			if !seen[key.String()] {
+				seen[key.String()] = true
+				columns = append(columns, &tableColumn{header: key.String(), key: key})
+			}
+		}
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].header < columns[j].header
+	})
+	return columns
+}
+
+// tableCell formats the cell of a column in row, on a single line. Rows that
+// are nil show nil in their first cell.
+func (p *Printer) tableCell(s *state, row reflect.Value, col *tableColumn, first bool) string {
+	if (row.Kind() == reflect.Ptr || row.Kind() == reflect.Interface || row.Kind() == reflect.Map) && row.IsNil() {
+		if first {
+			return p.colorize("nil", p.Styles.Null)
+		}
+		return ""
+	}
+
+	var sb strings.Builder
+	cell := s.withLayout(newLayout(&sb, math.MaxInt))
+	cell.depth++ // the row is a level of its own, as in a list
+	cell.beginInline()
+
+	if row.Kind() == reflect.Struct {
+		fieldVal := row.Field(col.field.index)
+		omitTypeName := !p.isSpecialHandledType(fieldVal) && p.shouldOmitStructName(col.field.goName, fieldVal, row.Type().Field(col.field.index).Type)
+		p.formatFieldValue(cell, col.field, fieldVal, !omitTypeName)
+		return sb.String()
+	}
+
+	value := row.MapIndex(col.key.Convert(row.Type().Key()))
+	switch {
+	case !value.IsValid():
+		return ""
+	case p.isSecretName(col.header, value):
+		p.formatRedacted(cell, value)
+	default:
+		p.formatValue(cell, value)
+	}
+	return sb.String()
+}
+
+// fitsOnOneLine reports whether the rows of a table would fit on a single
+// line if they were laid out as a list instead
+func (p *Printer) fitsOnOneLine(columns []*tableColumn, rows int, elemType reflect.Type) bool {
+	// [T{ a: 1, b: 2 }, T{ a: 3, b: 4 }]
+	width := 2 + 2*(rows-1)
+	for i := 0; i < rows; i++ {
+		width += len(elemType.Name()) + 4
+		for j, col := range columns {
+			if col.cells[i] == "" {
+				continue
+			}
+			if j > 0 {
+				width += 2
+			}
+			width += len(col.header) + 2 + lipgloss.Width(col.cells[i])
+		}
+	}
+	return width <= p.MaxWidth
+}
+
+// layoutTable lays out the columns of a table with length rows, dropping
+// the columns that don't fit within MaxWidth
+func (p *Printer) layoutTable(s *state, length int, rows []int, columns []*tableColumn) {
+	for _, col := range columns {
+		col.width = lipgloss.Width(col.header)
+		for _, cell := range col.cells {
+			col.width = max(col.width, lipgloss.Width(cell))
+		}
+	}
+
+	// Keep as many columns as fit, along with the marker of the dropped ones,
+	// within the indented lines of the table
+	available := p.MaxWidth - 2
+	shown := len(columns)
+	for shown > 1 && tableWidth(columns[:shown], len(columns)-shown) > available {
+		shown--
+	}
+
+	lines := make([]string, 0, len(rows)+3)
+	header := make([]string, shown)
+	for j, col := range columns[:shown] {
+		if col.field.unexported {
+			header[j] = p.colorize(col.header, p.Styles.Unexported)
+		} else {
+			header[j] = p.colorize(col.header, p.Styles.Field)
+		}
+	}
+	line := tableLine(header, columns[:shown])
+	if dropped := len(columns) - shown; dropped > 0 {
+		line += tableColumnGap + p.colorize(fmt.Sprintf("+%d columns", dropped), p.Styles.Comment)
+	}
+	lines = append(lines, line)
+
+	cells := make([]string, shown)
+	last := -1
+	for i, index := range rows {
+		if omitted := index - last - 1; omitted > 0 {
+			lines = append(lines, p.colorize(fmt.Sprintf("... %d more rows ...", omitted), p.Styles.Comment))
+		}
+		for j, col := range columns[:shown] {
+			cells[j] = col.cells[i]
+		}
+		lines = append(lines, tableLine(cells, columns[:shown]))
+		last = index
+	}
+	if omitted := length - last - 1; omitted > 0 {
+		lines = append(lines, p.colorize(fmt.Sprintf("... %d more rows ...", omitted), p.Styles.Comment))
+	}
+	if len(rows) < length {
+		lines = append(lines, p.colorize(fmt.Sprintf("// len() = %d", length), p.Styles.Comment))
+	}
+
+	// Tables are always laid out one row per line
+	s.begin(0)
+	s.forceBreak()
+	s.text("[")
+	s.indent()
+	for _, line := range lines {
+		s.brk("", false)
+		s.text(line)
+	}
+	s.dedent()
+	s.brk("", false)
+	s.text("]")
+	s.end()
+}
+
+// tableWidth returns the width of the lines of columns, followed by the
+// marker of the dropped columns if there are any
+func tableWidth(columns []*tableColumn, dropped int) int {
+	width := 0
+	for j, col := range columns {
+		if j > 0 {
+			width += len(tableColumnGap)
+		}
+		width += col.width
+	}
+	if dropped > 0 {
+		width += len(tableColumnGap) + len(fmt.Sprintf("+%d columns", dropped))
+	}
+	return width
+}
+
+// tableLine joins cells into a line, padding each to the width of its column
+func tableLine(cells []string, columns []*tableColumn) string {
+	var sb strings.Builder
+	for j, cell := range cells {
+		if j > 0 {
+			sb.WriteString(tableColumnGap)
+		}
+		sb.WriteString(cell)
+		if j < len(cells)-1 {
+			sb.WriteString(strings.Repeat(" ", columns[j].width-lipgloss.Width(cell)))
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
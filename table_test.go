@@ -0,0 +1,127 @@
+package pretty
+
+import "testing"
+
+type tableUser struct {
+	ID       int
+	Name     string
+	Email    string `pretty:"name=email"`
+	Password string `pretty:"secret"`
+	Tags     []string
+}
+
+type tableMember struct {
+	ID   int
+	Name string
+}
+
+func TestTable(t *testing.T) {
+	users := []tableUser{
+		{ID: 1, Name: "Alice", Email: "alice@example.com", Password: "hunter2", Tags: []string{"admin"}},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	}
+	printer := New().WithColorMode(ColorNever).WithTableMode(TableAlways)
+
+	tests := []struct {
+		name     string
+		printer  *Printer
+		input    interface{}
+		expected string
+	}{
+		{
+			name:    "structs",
+			printer: printer,
+			input:   users,
+			expected: `[
+  ID  Name     email                Password          Tags
+  1   "Alice"  "alice@example.com"  <redacted len=7>  ["admin"]
+  2   "Bob"    "bob@example.com"    <redacted len=0>  []
+]`,
+		},
+		{
+			name:    "nested tables are lists",
+			printer: printer,
+			input: []struct {
+				Team    string
+				Members []tableMember
+			}{{Team: "a", Members: []tableMember{{1, "x"}, {2, "y"}}}, {Team: "b"}},
+			expected: `[
+  Team  Members
+  "a"   [tableMember{ ID: 1, Name: "x" }, tableMember{ ID: 2, Name: "y" }]
+  "b"   []
+]`,
+		},
+		{
+			name:    "inline fields are lists",
+			printer: printer,
+			input: struct {
+				Members []tableMember `pretty:"inline"`
+			}{Members: []tableMember{{1, "x"}, {2, "y"}}},
+			expected: `{ Members: [tableMember{ ID: 1, Name: "x" }, tableMember{ ID: 2, Name: "y" }] }`,
+		},
+		{
+			name:    "dropped columns",
+			printer: printer.WithMaxWidth(40),
+			input:   users,
+			expected: `[
+  ID  Name  +3 columns
+  1   "Alice"
+  2   "Bob"
+]`,
+		},
+		{
+			name:    "truncated rows",
+			printer: printer.WithMaxSliceLength(2),
+			input:   []*tableUser{&users[0], nil, nil, &users[1]},
+			expected: `[
+  ID  Name     email                Password          Tags
+  1   "Alice"  "alice@example.com"  <redacted len=7>  ["admin"]
+  ... 2 more rows ...
+  2   "Bob"    "bob@example.com"    <redacted len=0>  []
+  // len() = 4
+]`,
+		},
+		{
+			name:    "maps",
+			printer: printer,
+			input:   []map[string]any{{"a": 1, "b": "x"}, {"b": map[string]int{"y": 2}, "c": true}, nil},
+			expected: `[
+  a    b         c
+  1    "x"
+       { y: 2 }  true
+  nil
+]`,
+		},
+		{
+			name:     "auto fits on one line",
+			printer:  printer.WithTableMode(TableAuto),
+			input:    []struct{ X, Y int }{{1, 2}, {3, 4}},
+			expected: `[{ X: 1, Y: 2 }, { X: 3, Y: 4 }]`,
+		},
+		{
+			name:    "auto too wide",
+			printer: printer.WithTableMode(TableAuto).WithMaxWidth(20),
+			input:   []struct{ X, Y int }{{1, 2}, {3, 4}},
+			expected: `[
+  X  Y
+  1  2
+  3  4
+]`,
+		},
+		{
+			name:     "never",
+			printer:  printer.WithTableMode(TableNever).WithMaxWidth(200),
+			input:    users[:1],
+			expected: `[tableUser{ ID: 1, Name: "Alice", email: "alice@example.com", Password: <redacted len=7>, Tags: ["admin"] }]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.printer.Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package pretty
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldLayout is how a struct field's value is laid out, as set by its tag
+type fieldLayout int
+
+const (
+	layoutAuto      fieldLayout = iota // single-line if it fits, multi-line otherwise
+	layoutInline                       // always on a single line
+	layoutMultiline                    // always on multiple lines
+)
+
+// fieldInfo describes how a struct field is printed
+type fieldInfo struct {
+	index      int
+	goName     string
+	name       string
+	omitEmpty  bool
+	secret     bool
+	layout     fieldLayout
+	unexported bool
+
+	truncate    TruncateStrategy
+	hasTruncate bool // whether the tag sets the truncate strategy
+}
+
+// structFieldsCache and allStructFieldsCache hold the parsed fields of each
+// struct type, as []fieldInfo, without and with the unexported fields
+var structFieldsCache, allStructFieldsCache sync.Map
+
+// structFields returns the exported fields of a struct type that are shown,
+// in declaration order, as configured by their pretty tags:
+//
+//	Field int `pretty:"-"`                  // never shown
+//	Field int `pretty:"name=field"`         // shown as field
+//	Field int `pretty:"omitempty"`          // not shown when it holds the zero value
+//	Field T   `pretty:"inline"`             // always on a single line
+//	Field T   `pretty:"multiline"`          // always on multiple lines
+//	Field T   `pretty:"secret"`             // always redacted
+//	Field []T `pretty:"truncate=tail"`      // truncated with TruncateTail
+//	Field T   `pretty:"name=t,omitempty"`   // options can be combined
+func structFields(typ reflect.Type) []fieldInfo {
+	return cachedStructFields(&structFieldsCache, typ, false)
+}
+
+// allStructFields returns the fields of a struct type that are shown when
+// unexported fields are, see structFields
+func allStructFields(typ reflect.Type) []fieldInfo {
+	return cachedStructFields(&allStructFieldsCache, typ, true)
+}
+
+// cachedStructFields returns the shown fields of a struct type from cache,
+// parsing them on first use
+func cachedStructFields(cache *sync.Map, typ reflect.Type, unexported bool) []fieldInfo {
+	if cached, ok := cache.Load(typ); ok {
+		return cached.([]fieldInfo)
+	}
+
+	fields := make([]fieldInfo, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() && (!unexported || field.Name == "_") {
+			continue
+		}
+		info, ok := parseFieldTag(field.Tag.Get("pretty"))
+		if !ok {
+			continue
+		}
+		info.index = i
+		info.goName = field.Name
+		info.unexported = !field.IsExported()
+		if info.name == "" {
+			info.name = field.Name
+		}
+		fields = append(fields, info)
+	}
+
+	cached, _ := cache.LoadOrStore(typ, fields)
+	return cached.([]fieldInfo)
+}
+
+// parseFieldTag parses the value of a pretty tag, returning false if the
+// field is hidden. Unknown options are ignored.
+func parseFieldTag(tag string) (fieldInfo, bool) {
+	var info fieldInfo
+	if tag == "-" {
+		return info, false
+	}
+
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		switch {
+		case strings.HasPrefix(opt, "name="):
+			info.name = strings.TrimPrefix(opt, "name=")
+		case opt == "omitempty":
+			info.omitEmpty = true
+		case opt == "secret":
+			info.secret = true
+		case opt == "inline":
+			info.layout = layoutInline
+		case opt == "multiline":
+			info.layout = layoutMultiline
+		case strings.HasPrefix(opt, "truncate="):
+			info.truncate, info.hasTruncate = truncateStrategies[strings.TrimPrefix(opt, "truncate=")]
+		}
+	}
+	return info, true
+}
+
+// fieldsOf returns the fields of a struct type that are shown, including the
+// unexported ones with ShowUnexported
+func (p *Printer) fieldsOf(typ reflect.Type) []fieldInfo {
+	if p.ShowUnexported {
+		return allStructFields(typ)
+	}
+	return structFields(typ)
+}
+
+// fieldName returns the name a field is shown with, dimmed if it is unexported
+func (p *Printer) fieldName(field fieldInfo) string {
+	if field.unexported {
+		return p.colorize(field.name, p.Styles.Unexported)
+	}
+	return field.name
+}
+
+// formatFieldValue formats the value of a struct field with the layout and
+// truncate strategy its tag asks for, redacting it if it is a secret
+func (p *Printer) formatFieldValue(s *state, field fieldInfo, val reflect.Value, includeTypeName bool) {
+	if p.isSecretField(field, val) {
+		p.formatRedacted(s, val)
+		return
+	}
+
+	// The strategy only applies to the slice of the field, one level deeper,
+	// and not to the slices nested in it
+	if field.hasTruncate {
+		truncate, truncateDepth := s.truncate, s.truncateDepth
+		s.truncate, s.truncateDepth = field.truncate, s.depth+1
+		defer func() { s.truncate, s.truncateDepth = truncate, truncateDepth }()
+	}
+
+	switch field.layout {
+	case layoutInline:
+		s.beginInline()
+		defer s.endInline()
+	case layoutMultiline:
+		s.breakNextGroup()
+		defer s.clearBreakNext()
+	}
+
+	p.formatValueWithOptions(s, val, includeTypeName)
+}
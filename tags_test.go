@@ -0,0 +1,155 @@
+package pretty
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type taggedUser struct {
+	ID       int    `pretty:"name=id"`
+	Name     string `pretty:"name=name,omitempty"`
+	Password string `pretty:"-"`
+	Email    string `pretty:"omitempty"`
+	Internal string
+}
+
+type taggedLayout struct {
+	Tags   []string          `pretty:"inline"`
+	Labels map[string]string `pretty:"multiline"`
+	Count  int               `pretty:"multiline"`
+	Other  []int
+}
+
+type taggedError struct {
+	Op     string `pretty:"name=op"`
+	Detail string `pretty:"omitempty"`
+	Secret string `pretty:"-"`
+}
+
+func (e *taggedError) Error() string { return e.Op + " failed" }
+
+func TestStructTags(t *testing.T) {
+	printer := New().WithColorMode(ColorNever)
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		width    int
+		expected string
+	}{
+		{
+			name:     "hidden, renamed and omitted",
+			input:    taggedUser{ID: 1, Password: "hunter2", Internal: "x"},
+			width:    100,
+			expected: `taggedUser{ id: 1, Internal: "x" }`,
+		},
+		{
+			name:     "omitempty keeps non-zero values",
+			input:    taggedUser{ID: 1, Name: "Alice", Email: "a@example.com"},
+			width:    100,
+			expected: `taggedUser{ id: 1, name: "Alice", Email: "a@example.com", Internal: "" }`,
+		},
+		{
+			name:  "inline and multiline",
+			input: taggedLayout{Tags: []string{"alpha", "beta", "gamma", "delta"}, Labels: map[string]string{"a": "b"}, Count: 3, Other: []int{1, 2}},
+			width: 30,
+			expected: `taggedLayout{
+  Tags: ["alpha", "beta", "gamma", "delta"],
+  Labels: {
+    a: "b"
+  },
+  Count: 3,
+  Other: [1, 2]
+}`,
+		},
+		{
+			name:     "error fields",
+			input:    error(&taggedError{Op: "read", Secret: "s"}),
+			width:    100,
+			expected: `*pretty.taggedError("read failed") { op: "read" }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := printer.WithMaxWidth(tt.width).Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStructTagsInlineIgnoresForcedBreaks(t *testing.T) {
+	type withErr struct {
+		Err error `pretty:"inline"`
+	}
+
+	err := errors.Join(errors.New("a"), errors.New("b"))
+	result := New().WithColorMode(ColorNever).Print(withErr{Err: err})
+	expected := `withErr{ Err: *errors.joinError("a\nb") ↳ *errors.errorString("a") ↳ *errors.errorString("b") }`
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
+func TestStructFieldsCached(t *testing.T) {
+	typ := reflect.TypeOf(taggedUser{})
+	first := structFields(typ)
+	second := structFields(typ)
+	if len(first) != 4 || &first[0] != &second[0] {
+		t.Errorf("structFields() = %v, want the same 4 cached fields", first)
+	}
+}
+
+type unexportedNode struct {
+	Name  string
+	id    int
+	c     complex128
+	next  *unexportedNode
+	tags  []string
+	label string `pretty:"name=lbl,omitempty"`
+}
+
+func TestShowUnexported(t *testing.T) {
+	node := &unexportedNode{Name: "a", id: 7, c: 1 + 2i, tags: []string{"x", "y", "z", "w"}}
+	node.next = node
+
+	printer := New().WithColorMode(ColorNever).WithSequentialIDs(true).WithMaxSliceLength(2)
+
+	hidden := printer.Print(node)
+	if expected := `unexportedNode{ Name: "a" }`; hidden != expected {
+		t.Errorf("Print() = %q, want %q", hidden, expected)
+	}
+
+	result := printer.WithShowUnexported(true).Print(node)
+	expected := `unexportedNode{
+  Name: "a",
+  id: 7,
+  c: (1+2i),
+  next: →#1,
+  tags: [
+    "x",
+    ... 2 more elements ...,
+    "w",
+    // len() = 4
+  ]
+}#1`
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
+func TestShowUnexportedStyle(t *testing.T) {
+	printer := New().WithColorMode(ColorAlways).WithShowUnexported(true)
+	printer.Styles.Unexported = lipgloss.NewStyle().SetString("~")
+
+	result := printer.Print(struct{ id string }{id: "a"})
+	if !strings.Contains(result, printer.Styles.Unexported.Render("id")) {
+		t.Errorf("Print() = %q, want the field name in the Unexported style", result)
+	}
+}
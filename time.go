@@ -2,9 +2,29 @@ package pretty
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// TimeMode controls how a Printer shows times
+type TimeMode int
+
+const (
+	// TimeRelative shows times relative to now, such as "2 hours ago", followed
+	// by the time of day when they are more than 30 minutes away
+	TimeRelative TimeMode = iota
+	// TimeAbsolute shows times with the TimeLayout of the Printer
+	TimeAbsolute
+	// TimeBoth shows times relative to now followed by the TimeLayout of the
+	// Printer, such as "2 hours ago (2026-10-16 13:04:05 UTC)"
+	TimeBoth
+)
+
+// DefaultTimeLayout is the layout of absolute times when a Printer has no TimeLayout
+const DefaultTimeLayout = "2006-01-02 15:04:05 MST"
+
 // TimeFormatter configures and performs human-friendly relative time formatting
 type TimeFormatter struct {
 	// Reference time for calculating relative time (defaults to time.Now())
@@ -20,13 +40,70 @@ type TimeFormatter struct {
 	// Use friendly phrases like "just now", "last week", "next month"
 	FriendlyPhrases bool
 
-	// Show future times as "in X time" vs "X from now".
-	// "in %s" (default), or customize like "%s from now"
+	// Show future times with this template rather than the Future of the
+	// Locale, such as "%s from now" (default: "", the Locale's)
 	FutureFormat string
 
 	ZeroString string // String to show for zero time (default: "<zero>")
+
+	// Use calendar days, months and years in the location of Now rather than
+	// fixed lengths of time, for phrases like "yesterday at 11:04 PM" and
+	// "last Tuesday" (default: false)
+	Calendar bool
+
+	// Show up to this many units, such as "2 hours 13 minutes ago", starting
+	// from the unit picked by the thresholds (default: 1)
+	Precision int
+
+	// Show units abbreviated, such as "2h13m ago" and "3d ago" (default: false)
+	Abbreviated bool
+
+	// Round the last unit shown down or to the nearest (default: RoundFloor)
+	Rounding Rounding
+
+	// The language of units and phrases, such as "3 Tagen" and "gestern"
+	// (default: English)
+	Locale *Locale
 }
 
+// Rounding controls how a TimeFormatter rounds the last unit it shows
+type Rounding int
+
+const (
+	// RoundFloor rounds down, so 1h59m is "1 hour ago"
+	RoundFloor Rounding = iota
+	// RoundNearest rounds to the nearest, so 1h59m is "2 hours ago"
+	RoundNearest
+)
+
+// timeUnit is a unit relative times are shown in, named as in the Units of
+// a Locale
+type timeUnit struct {
+	size time.Duration
+	name string
+}
+
+// timeUnits are the units of relative times, smallest first
+var timeUnits = []timeUnit{
+	{time.Second, "second"},
+	{time.Minute, "minute"},
+	{time.Hour, "hour"},
+	{24 * time.Hour, "day"},
+	{7 * 24 * time.Hour, "week"},
+	{30 * 24 * time.Hour, "month"}, // Approximate
+	{365 * 24 * time.Hour, "year"}, // Approximate
+}
+
+const (
+	unitSecond = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
 // NewTimeFormatter creates a new TimeFormatter with sensible defaults
 func NewTimeFormatter() *TimeFormatter {
 	return &TimeFormatter{
@@ -38,8 +115,8 @@ func NewTimeFormatter() *TimeFormatter {
 		WeekThreshold:   30 * 24 * time.Hour,
 		MonthThreshold:  365 * 24 * time.Hour,
 		FriendlyPhrases: true,
-		FutureFormat:    "in %s",
 		ZeroString:      "<zero>",
+		Locale:          English,
 	}
 }
 
@@ -106,6 +183,41 @@ func (tf *TimeFormatter) WithFutureFormat(format string) *TimeFormatter {
 	return &newTF
 }
 
+// WithLocale sets the language of units and phrases
+func (tf *TimeFormatter) WithLocale(locale *Locale) *TimeFormatter {
+	newTF := *tf
+	newTF.Locale = locale
+	return &newTF
+}
+
+// WithCalendar enables/disables calendar days, months and years in the location of Now
+func (tf *TimeFormatter) WithCalendar(enabled bool) *TimeFormatter {
+	newTF := *tf
+	newTF.Calendar = enabled
+	return &newTF
+}
+
+// WithPrecision sets how many units are shown, such as "2 hours 13 minutes ago"
+func (tf *TimeFormatter) WithPrecision(units int) *TimeFormatter {
+	newTF := *tf
+	newTF.Precision = units
+	return &newTF
+}
+
+// WithAbbreviated enables/disables abbreviated units like "2h13m ago"
+func (tf *TimeFormatter) WithAbbreviated(enabled bool) *TimeFormatter {
+	newTF := *tf
+	newTF.Abbreviated = enabled
+	return &newTF
+}
+
+// WithRounding sets how the last unit shown is rounded
+func (tf *TimeFormatter) WithRounding(rounding Rounding) *TimeFormatter {
+	newTF := *tf
+	newTF.Rounding = rounding
+	return &newTF
+}
+
 // Format formats a time.Time value into a human-friendly relative string
 func (tf *TimeFormatter) Format(t time.Time) string {
 	if t.IsZero() {
@@ -117,113 +229,200 @@ func (tf *TimeFormatter) Format(t time.Time) string {
 		now = time.Now()
 	}
 
+	if tf.Calendar {
+		if result, ok := tf.formatCalendar(t, now); ok {
+			return result
+		}
+	}
+
 	diff := now.Sub(t)
 	absDiff := diff.Abs()
-
-	var result string
 	isPast := diff > 0
 
-	switch {
-	case absDiff < tf.SecondThreshold:
-		seconds := int(absDiff.Seconds())
-		if tf.FriendlyPhrases && seconds < 10 {
-			result = "just now"
-			isPast = true // "just now" is always considered past
-		} else if seconds == 1 {
-			result = "1 second"
-		} else {
-			result = fmt.Sprintf("%d seconds", seconds)
-		}
+	largest := tf.largestUnit(absDiff)
+	if largest == unitSecond && tf.FriendlyPhrases && absDiff < 10*time.Second {
+		return tf.locale().phrase("just now") // "just now" is always considered past
+	}
 
-	case absDiff < tf.MinuteThreshold:
-		minutes := int(absDiff.Minutes())
-		if minutes == 1 {
-			result = "1 minute"
-		} else {
-			result = fmt.Sprintf("%d minutes", minutes)
+	// Rounding up can reach the next unit, such as 59m40s to 1 hour
+	smallest := max(largest-max(tf.Precision, 1)+1, unitSecond)
+	if tf.Rounding == RoundNearest {
+		absDiff = absDiff.Round(timeUnits[smallest].size)
+		if next := tf.largestUnit(absDiff); next != largest {
+			largest = next
+			smallest = max(largest-max(tf.Precision, 1)+1, unitSecond)
 		}
+	}
 
-	case absDiff < tf.HourThreshold:
-		hours := int(absDiff.Hours())
-		if hours == 1 {
-			result = "1 hour"
-		} else {
-			result = fmt.Sprintf("%d hours", hours)
+	var parts []string
+	for unit := largest; unit >= smallest; unit-- {
+		n := int(absDiff / timeUnits[unit].size)
+		absDiff -= time.Duration(n) * timeUnits[unit].size
+		// The largest unit is always shown, even as "0 minutes"
+		if n == 0 && unit != largest {
+			continue
 		}
+		parts = append(parts, tf.unitString(n, unit))
+	}
 
-	case absDiff < tf.DayThreshold:
-		days := int(absDiff.Hours() / 24)
-		if tf.FriendlyPhrases && days == 1 {
-			if isPast {
-				result = "yesterday"
-			} else {
-				result = "tomorrow"
-			}
-			isPast = true // handled by the phrase itself
-		} else if days == 1 {
-			result = "1 day"
-		} else {
-			result = fmt.Sprintf("%d days", days)
-		}
+	// A single one of a day, week, month or year has its own phrase
+	if tf.FriendlyPhrases && len(parts) == 1 && parts[0] == tf.unitString(1, largest) && largest >= unitDay {
+		return tf.friendlyPhrase(isPast, largest)
+	}
 
-	case absDiff < tf.WeekThreshold:
-		weeks := int(absDiff.Hours() / (24 * 7))
-		if tf.FriendlyPhrases && weeks == 1 {
-			if isPast {
-				result = "last week"
-			} else {
-				result = "next week"
-			}
-			isPast = true // handled by the phrase itself
-		} else if weeks == 1 {
-			result = "1 week"
-		} else {
-			result = fmt.Sprintf("%d weeks", weeks)
+	separator := tf.locale().UnitSeparator
+	if tf.Abbreviated {
+		separator = ""
+	}
+	return tf.direction(isPast, strings.Join(parts, separator))
+}
+
+// largestUnit returns the largest unit a duration is shown in, as set by the thresholds
+func (tf *TimeFormatter) largestUnit(d time.Duration) int {
+	thresholds := []time.Duration{
+		tf.SecondThreshold,
+		tf.MinuteThreshold,
+		tf.HourThreshold,
+		tf.DayThreshold,
+		tf.WeekThreshold,
+		tf.MonthThreshold,
+	}
+	for unit, threshold := range thresholds {
+		if d < threshold {
+			return unit
 		}
+	}
+	return unitYear
+}
 
-	case absDiff < tf.MonthThreshold:
-		months := int(absDiff.Hours() / (24 * 30)) // Approximate
-		if tf.FriendlyPhrases && months == 1 {
-			if isPast {
-				result = "last month"
-			} else {
-				result = "next month"
-			}
-			isPast = true // handled by the phrase itself
-		} else if months == 1 {
-			result = "1 month"
-		} else {
-			result = fmt.Sprintf("%d months", months)
+// locale returns the Locale of the formatter, or English if it has none
+func (tf *TimeFormatter) locale() *Locale {
+	if tf.Locale == nil {
+		return English
+	}
+	return tf.Locale
+}
+
+// unitString returns n of a unit, such as "3 days", or "3d" when abbreviated
+func (tf *TimeFormatter) unitString(n, unit int) string {
+	if tf.Abbreviated {
+		return tf.locale().abbreviation(n, timeUnits[unit].name)
+	}
+	return tf.locale().unit(n, timeUnits[unit].name)
+}
+
+// direction returns result as a time in the past, such as "3 days ago", or
+// in the future with the FutureFormat, such as "in 3 days"
+func (tf *TimeFormatter) direction(isPast bool, result string) string {
+	locale := tf.locale()
+	switch {
+	case isPast:
+		return fmt.Sprintf(locale.Past, result)
+	case tf.FutureFormat != "":
+		return fmt.Sprintf(tf.FutureFormat, result)
+	default:
+		return fmt.Sprintf(locale.Future, result)
+	}
+}
+
+// formatCalendar formats t relative to now in calendar days, weeks, months
+// and years in the location of now. Times on the same day as now are left
+// to Format, and reported as not ok.
+func (tf *TimeFormatter) formatCalendar(t, now time.Time) (string, bool) {
+	t = t.In(now.Location())
+	days := calendarDays(t, now)
+	if days == 0 {
+		return "", false
+	}
+
+	locale := tf.locale()
+	isPast := days > 0
+	days = max(days, -days)
+	var result string
+	switch {
+	case days == 1 && tf.FriendlyPhrases:
+		if isPast {
+			return fmt.Sprintf(locale.phrase("yesterday at %s"), t.Format(locale.TimeOfDay)), true
 		}
+		return fmt.Sprintf(locale.phrase("tomorrow at %s"), t.Format(locale.TimeOfDay)), true
+
+	case days < 7 && tf.FriendlyPhrases:
+		return locale.weekday(isPast, t.Weekday()), true
+
+	case days < 7:
+		result = tf.unitString(days, unitDay)
 
 	default:
-		years := int(absDiff.Hours() / (24 * 365)) // Approximate
-		if tf.FriendlyPhrases && years == 1 {
-			if isPast {
-				result = "last year"
-			} else {
-				result = "next year"
+		months := calendarMonths(t, now)
+		isPast = months > 0 || (months == 0 && isPast)
+		months = max(months, -months)
+
+		switch {
+		case months == 0:
+			weeks := days / 7
+			if tf.FriendlyPhrases && weeks == 1 {
+				return tf.friendlyPhrase(isPast, unitWeek), true
+			}
+			result = tf.unitString(weeks, unitWeek)
+		case months < 12:
+			if tf.FriendlyPhrases && months == 1 {
+				return tf.friendlyPhrase(isPast, unitMonth), true
 			}
-			isPast = true // handled by the phrase itself
-		} else if years == 1 {
-			result = "1 year"
-		} else {
-			result = fmt.Sprintf("%d years", years)
+			result = tf.unitString(months, unitMonth)
+		default:
+			years := months / 12
+			if tf.FriendlyPhrases && years == 1 {
+				return tf.friendlyPhrase(isPast, unitYear), true
+			}
+			result = tf.unitString(years, unitYear)
 		}
 	}
 
-	// Handle friendly phrases that already contain directionality
-	if tf.FriendlyPhrases && (result == "just now" || result == "yesterday" || result == "tomorrow" ||
-		result == "last week" || result == "next week" || result == "last month" || result == "next month" ||
-		result == "last year" || result == "next year") {
-		return result
+	return tf.direction(isPast, result), true
+}
+
+// calendarDays returns the number of calendar days from the day of t to the
+// day of now, negative if t is after now
+func calendarDays(t, now time.Time) int {
+	// Dates in UTC have no daylight saving time changes
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 	}
+	return int(day(now).Sub(day(t)).Hours() / 24)
+}
 
-	// Add direction for regular phrases
-	if isPast {
-		return result + " ago"
-	} else {
-		return fmt.Sprintf(tf.FutureFormat, result)
+// calendarMonths returns the number of whole calendar months from t to now,
+// negative if t is after now, so that Feb 28 to Mar 28 is 1 month
+func calendarMonths(t, now time.Time) int {
+	if t.After(now) {
+		return -calendarMonths(now, t)
+	}
+	months := (now.Year()-t.Year())*12 + int(now.Month()-t.Month())
+	if now.Day() < t.Day() || (now.Day() == t.Day() && timeOfDay(now) < timeOfDay(t)) {
+		months--
+	}
+	return months
+}
+
+// timeOfDay returns the time elapsed since the start of the day of t
+func timeOfDay(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(t.Nanosecond())
+}
+
+// friendlyPhrase returns the phrase for a single day, week, month or year
+// in the past or the future, such as "yesterday" or "next week"
+func (tf *TimeFormatter) friendlyPhrase(isPast bool, unit int) string {
+	switch {
+	case unit == unitDay && isPast:
+		return tf.locale().phrase("yesterday")
+	case unit == unitDay:
+		return tf.locale().phrase("tomorrow")
+	case isPast:
+		return tf.locale().phrase("last " + timeUnits[unit].name)
+	default:
+		return tf.locale().phrase("next " + timeUnits[unit].name)
 	}
 }
 
@@ -231,3 +430,153 @@ func (tf *TimeFormatter) Format(t time.Time) string {
 func Time(t time.Time) string {
 	return NewTimeFormatter().Format(t)
 }
+
+// DurationStyle controls how a DurationFormatter writes units
+type DurationStyle int
+
+const (
+	// DurationCompact writes units without spaces, such as "1h2m3.5s"
+	DurationCompact DurationStyle = iota
+	// DurationShort writes abbreviated units separated by spaces, such as "1h 2m 3.5s"
+	DurationShort
+	// DurationLong writes units as words, such as "1 hour 2 minutes 3.5 seconds"
+	DurationLong
+)
+
+// durationUnit is a unit a duration is written in
+type durationUnit struct {
+	size  time.Duration
+	short string
+	long  string
+}
+
+// durationUnits are the units of durations of a second or more, largest
+// first. Weeks, months and years vary in length, so days are the largest.
+var durationUnits = []durationUnit{
+	{24 * time.Hour, "d", "day"},
+	{time.Hour, "h", "hour"},
+	{time.Minute, "m", "minute"},
+	{time.Second, "s", "second"},
+}
+
+// subsecondUnits are the units of durations under a second, largest first
+var subsecondUnits = []durationUnit{
+	{time.Millisecond, "ms", "millisecond"},
+	{time.Microsecond, "µs", "microsecond"},
+	{time.Nanosecond, "ns", "nanosecond"},
+}
+
+// DurationFormatter configures and performs human-friendly duration formatting
+type DurationFormatter struct {
+	// Style controls how units are written (default: DurationCompact)
+	Style DurationStyle
+
+	// Precision is the maximum number of units shown, rounding the last one to
+	// the nearest, such as "1h 2m" for 1h2m3.5s with a precision of 2.
+	// If 0, all units are shown down to fractions of a second (default)
+	Precision int
+}
+
+// NewDurationFormatter creates a new DurationFormatter with sensible defaults
+func NewDurationFormatter() *DurationFormatter {
+	return &DurationFormatter{
+		Style: DurationCompact,
+	}
+}
+
+// WithStyle sets how units are written
+func (df *DurationFormatter) WithStyle(style DurationStyle) *DurationFormatter {
+	newDF := *df
+	newDF.Style = style
+	return &newDF
+}
+
+// WithPrecision sets the maximum number of units shown
+func (df *DurationFormatter) WithPrecision(units int) *DurationFormatter {
+	newDF := *df
+	newDF.Precision = units
+	return &newDF
+}
+
+// Format formats a time.Duration value into a human-friendly string
+func (df *DurationFormatter) Format(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+	}
+	abs := d.Abs()
+
+	if abs < time.Second {
+		return sign + df.formatSubsecond(abs)
+	}
+
+	// Round to the smallest unit shown
+	if df.Precision > 0 {
+		first := 0
+		for abs < durationUnits[first].size {
+			first++
+		}
+		last := min(first+df.Precision-1, len(durationUnits)-1)
+		abs = abs.Round(durationUnits[last].size)
+	}
+
+	var parts []string
+	for i, unit := range durationUnits {
+		if df.Precision > 0 && len(parts) == df.Precision {
+			break
+		}
+		n := abs / unit.size
+		abs -= n * unit.size
+
+		// Seconds carry the fraction of a second that is left
+		value := strconv.FormatInt(int64(n), 10)
+		if i == len(durationUnits)-1 && abs > 0 {
+			value = strconv.FormatFloat(float64(n)+abs.Seconds(), 'f', -1, 64)
+		} else if n == 0 {
+			continue
+		}
+		parts = append(parts, df.unit(value, unit))
+	}
+
+	separator := " "
+	if df.Style == DurationCompact {
+		separator = ""
+	}
+	return sign + strings.Join(parts, separator)
+}
+
+// formatSubsecond formats a duration under a second in the largest unit it
+// has a whole one of, such as "1.5ms"
+func (df *DurationFormatter) formatSubsecond(d time.Duration) string {
+	if d == 0 {
+		return df.unit("0", durationUnits[len(durationUnits)-1])
+	}
+	for _, unit := range subsecondUnits {
+		if d >= unit.size {
+			value := float64(d) / float64(unit.size)
+			if df.Precision > 0 {
+				value = math.Round(value)
+			}
+			return df.unit(strconv.FormatFloat(value, 'f', -1, 64), unit)
+		}
+	}
+	return ""
+}
+
+// unit writes value in unit, in the style of the formatter
+func (df *DurationFormatter) unit(value string, unit durationUnit) string {
+	switch df.Style {
+	case DurationLong:
+		if value == "1" {
+			return value + " " + unit.long
+		}
+		return value + " " + unit.long + "s"
+	default:
+		return value + unit.short
+	}
+}
+
+// Duration formats a time.Duration value into a human-friendly string using default settings
+func Duration(d time.Duration) string {
+	return NewDurationFormatter().Format(d)
+}
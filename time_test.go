@@ -359,8 +359,11 @@ func TestTimeFormatterDefaults(t *testing.T) {
 	if tf.FriendlyPhrases != true {
 		t.Errorf("Default UseFriendlyPhrases = %v, want true", tf.FriendlyPhrases)
 	}
-	if tf.FutureFormat != "in %s" {
-		t.Errorf("Default FutureFormat = %q, want 'in %%s'", tf.FutureFormat)
+	if tf.FutureFormat != "" {
+		t.Errorf("Default FutureFormat = %q, want empty", tf.FutureFormat)
+	}
+	if tf.Locale != English {
+		t.Errorf("Default Locale = %v, want English", tf.Locale.Tag)
 	}
 }
 
@@ -396,3 +399,132 @@ func TestPrinterTimeIntegration(t *testing.T) {
 		t.Errorf("Expected result to contain '<zero>' for zero time, got: %s", zeroResult)
 	}
 }
+
+func TestDurationFormatter_Format(t *testing.T) {
+	tests := []struct {
+		name     string
+		df       *DurationFormatter
+		input    time.Duration
+		expected string
+	}{
+		{"zero", NewDurationFormatter(), 0, "0s"},
+		{"nanoseconds", NewDurationFormatter(), 12, "12ns"},
+		{"microseconds", NewDurationFormatter(), 1500 * time.Nanosecond, "1.5µs"},
+		{"milliseconds", NewDurationFormatter(), 250 * time.Millisecond, "250ms"},
+		{"compact", NewDurationFormatter(), time.Hour + 2*time.Minute + 3500*time.Millisecond, "1h2m3.5s"},
+		{"whole units only", NewDurationFormatter(), 90 * time.Minute, "1h30m"},
+		{"days", NewDurationFormatter(), 49 * time.Hour, "2d1h"},
+		{"negative", NewDurationFormatter(), -time.Minute, "-1m"},
+		{"short", NewDurationFormatter().WithStyle(DurationShort), time.Hour + 2*time.Minute + 3500*time.Millisecond, "1h 2m 3.5s"},
+		{"precision", NewDurationFormatter().WithStyle(DurationShort).WithPrecision(2), time.Hour + 2*time.Minute + 3500*time.Millisecond, "1h 2m"},
+		{"precision rounds", NewDurationFormatter().WithPrecision(1), 59*time.Minute + 59*time.Second, "1h"},
+		{"precision of fractions", NewDurationFormatter().WithPrecision(1), 1500 * time.Microsecond, "2ms"},
+		{"long", NewDurationFormatter().WithStyle(DurationLong), time.Hour + 2*time.Minute, "1 hour 2 minutes"},
+		{"long fractions", NewDurationFormatter().WithStyle(DurationLong), 1500 * time.Millisecond, "1.5 seconds"},
+		{"long zero", NewDurationFormatter().WithStyle(DurationLong), 0, "0 seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.df.Format(tt.input)
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrinterDurationIntegration(t *testing.T) {
+	printer := New().WithColorMode(ColorNever)
+
+	data := struct {
+		Timeout  time.Duration
+		Interval *time.Duration
+		Retries  []time.Duration
+	}{
+		Timeout: time.Hour,
+		Retries: []time.Duration{100 * time.Millisecond, 2 * time.Second},
+	}
+
+	result := printer.Print(data)
+	expected := "{ Timeout: 1h, Interval: nil, Retries: [100ms, 2s] }"
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
+func TestTimeFormatter_Calendar(t *testing.T) {
+	// Friday 1:00 AM in New York
+	loc := time.FixedZone("EDT", -4*60*60)
+	now := time.Date(2026, 10, 16, 1, 0, 0, 0, loc)
+	tf := NewTimeFormatter().WithNow(now).WithCalendar(true)
+
+	tests := []struct {
+		name     string
+		tf       *TimeFormatter
+		input    time.Time
+		expected string
+	}{
+		{"same day", tf, now.Add(-30 * time.Minute), "30 minutes ago"},
+		{"yesterday", tf, time.Date(2026, 10, 15, 23, 4, 0, 0, loc), "yesterday at 11:04 PM"},
+		{"yesterday in another zone", tf, time.Date(2026, 10, 16, 3, 4, 0, 0, time.UTC), "yesterday at 11:04 PM"},
+		{"tomorrow", tf, time.Date(2026, 10, 17, 9, 30, 0, 0, loc), "tomorrow at 9:30 AM"},
+		{"last weekday", tf, time.Date(2026, 10, 13, 18, 0, 0, 0, loc), "last Tuesday"},
+		{"next weekday", tf, time.Date(2026, 10, 21, 8, 0, 0, 0, loc), "next Wednesday"},
+		{"days", tf.WithFriendlyPhrases(false), time.Date(2026, 10, 13, 18, 0, 0, 0, loc), "3 days ago"},
+		{"last week", tf, time.Date(2026, 10, 6, 12, 0, 0, 0, loc), "last week"},
+		{"weeks", tf, time.Date(2026, 9, 30, 12, 0, 0, 0, loc), "2 weeks ago"},
+		{"months", tf, time.Date(2026, 7, 16, 0, 0, 0, 0, loc), "3 months ago"},
+		{"years", tf, time.Date(2024, 10, 15, 0, 0, 0, 0, loc), "2 years ago"},
+		{"next month", tf, time.Date(2026, 11, 20, 0, 0, 0, 0, loc), "next month"},
+		{
+			name:     "a calendar month",
+			tf:       NewTimeFormatter().WithNow(time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC)).WithCalendar(true).WithFriendlyPhrases(false),
+			input:    time.Date(2026, 2, 28, 12, 0, 0, 0, time.UTC),
+			expected: "1 month ago",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.tf.Format(tt.input)
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeFormatter_Precision(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	tf := NewTimeFormatter().WithNow(now)
+
+	tests := []struct {
+		name     string
+		tf       *TimeFormatter
+		input    time.Time
+		expected string
+	}{
+		{"single unit floors", tf, now.Add(-(time.Hour + 59*time.Minute)), "1 hour ago"},
+		{"nearest", tf.WithRounding(RoundNearest), now.Add(-(time.Hour + 59*time.Minute)), "2 hours ago"},
+		{"nearest reaches the next unit", tf.WithRounding(RoundNearest), now.Add(-(59*time.Minute + 40*time.Second)), "1 hour ago"},
+		{"two units", tf.WithPrecision(2), now.Add(-(2*time.Hour + 13*time.Minute + 50*time.Second)), "2 hours 13 minutes ago"},
+		{"two units nearest", tf.WithPrecision(2).WithRounding(RoundNearest), now.Add(-(2*time.Hour + 13*time.Minute + 50*time.Second)), "2 hours 14 minutes ago"},
+		{"zero units are skipped", tf.WithPrecision(3), now.Add(-(2*time.Hour + 5*time.Second)), "2 hours 5 seconds ago"},
+		{"future", tf.WithPrecision(2), now.Add(10 * 24 * time.Hour), "in 1 week 3 days"},
+		{"whole friendly unit", tf.WithPrecision(2), now.Add(-24 * time.Hour), "yesterday"},
+		{"abbreviated", tf.WithPrecision(2).WithAbbreviated(true), now.Add(-(2*time.Hour + 13*time.Minute)), "2h13m ago"},
+		{"abbreviated days", tf.WithAbbreviated(true), now.Add(-3 * 24 * time.Hour), "3d ago"},
+		{"abbreviated future", tf.WithAbbreviated(true), now.Add(90 * time.Second), "in 1m"},
+		{"thresholds still apply", tf.WithPrecision(2).WithHourThreshold(48 * time.Hour), now.Add(-30 * time.Hour), "30 hours ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.tf.Format(tt.input)
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package pretty
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// TruncateStrategy controls which elements of slices and arrays longer than
+// MaxSliceLength are shown
+type TruncateStrategy int
+
+const (
+	// TruncateHeadTail shows the first half and the last half of the elements
+	TruncateHeadTail TruncateStrategy = iota
+	// TruncateHead shows the first elements, such as the oldest entries of a log
+	TruncateHead
+	// TruncateTail shows the last elements, such as the newest entries of a log
+	TruncateTail
+	// TruncateSample shows evenly spaced elements, including the first and the
+	// last, along with their indices, such as the points of a time series
+	TruncateSample
+	// TruncateSummary shows the first half and the last half of the elements,
+	// along with the min, max, mean and count of NaNs of numeric slices
+	TruncateSummary
+)
+
+// truncateStrategies maps the names used in field tags to strategies
+var truncateStrategies = map[string]TruncateStrategy{
+	"headtail": TruncateHeadTail,
+	"head":     TruncateHead,
+	"tail":     TruncateTail,
+	"sample":   TruncateSample,
+	"summary":  TruncateSummary,
+}
+
+// WithTruncateStrategy creates a new Printer with the specified truncation strategy
+func (p *Printer) WithTruncateStrategy(strategy TruncateStrategy) *Printer {
+	newP := p.copyPrinter()
+	newP.TruncateStrategy = strategy
+	return newP
+}
+
+// truncateStrategy returns the strategy of the slice being formatted, which
+// is set by the tag of its field or otherwise by the Printer
+func (p *Printer) truncateStrategy(s *state) TruncateStrategy {
+	if s.truncateDepth == s.depth {
+		return s.truncate
+	}
+	return p.TruncateStrategy
+}
+
+// truncatedIndices returns the indices of the elements shown of a slice of
+// length elements, in order
+func (p *Printer) truncatedIndices(strategy TruncateStrategy, length int) []int {
+	if p.MaxSliceLength <= 0 || length <= p.MaxSliceLength {
+		indices := make([]int, length)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	var indices []int
+	switch strategy {
+	case TruncateHead:
+		for i := 0; i < p.MaxSliceLength; i++ {
+			indices = append(indices, i)
+		}
+	case TruncateTail:
+		for i := length - p.MaxSliceLength; i < length; i++ {
+			indices = append(indices, i)
+		}
+	case TruncateSample:
+		if p.MaxSliceLength == 1 {
+			return []int{0}
+		}
+		for k := 0; k < p.MaxSliceLength; k++ {
+			indices = append(indices, int(math.Round(float64(k*(length-1))/float64(p.MaxSliceLength-1))))
+		}
+	default:
+		// Show half at beginning, half at end
+		showCount := max(p.MaxSliceLength/2, 1)
+		for i := 0; i < showCount; i++ {
+			indices = append(indices, i)
+		}
+		for i := max(length-showCount, showCount); i < length; i++ {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// formatTruncatedSlice formats a long slice by showing the elements picked
+// by the strategy, and a summary
+func (p *Printer) formatTruncatedSlice(s *state, val reflect.Value, strategy TruncateStrategy) {
+	totalLength := val.Len()
+
+	// Truncated slices are always laid out one element per line
+	c := s.beginCompound("[", "]", false, 0)
+	s.forceBreak()
+
+	// Samples show their indices instead of the number of elements between them
+	last := -1
+	for _, i := range p.truncatedIndices(strategy, totalLength) {
+		if omitted := i - last - 1; omitted > 0 && strategy != TruncateSample {
+			c.item()
+			s.text(p.colorize(fmt.Sprintf("... %d more elements ...", omitted), p.Styles.Comment))
+		}
+		c.item()
+		if strategy == TruncateSample {
+			s.text(p.colorize(fmt.Sprintf("[%d]:", i), p.Styles.Comment) + " ")
+		}
+		p.formatValue(s, val.Index(i))
+		last = i
+	}
+	if omitted := totalLength - last - 1; omitted > 0 && strategy != TruncateSample {
+		c.item()
+		s.text(p.colorize(fmt.Sprintf("... %d more elements ...", omitted), p.Styles.Comment))
+	}
+
+	// Add summary comment
+	summary := fmt.Sprintf("// len() = %d", totalLength)
+	if strategy == TruncateSummary {
+		summary += sliceStats(val)
+	}
+	c.item()
+	s.text(p.colorize(summary, p.Styles.Comment))
+
+	c.end()
+}
+
+// sliceStats returns the min, max and mean of a slice of numbers, along with
+// the count of NaNs of floats, or nothing if the elements aren't numbers
+func sliceStats(val reflect.Value) string {
+	var toFloat func(reflect.Value) float64
+	switch val.Type().Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		toFloat = func(v reflect.Value) float64 { return float64(v.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		toFloat = func(v reflect.Value) float64 { return float64(v.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		toFloat = func(v reflect.Value) float64 { return v.Float() }
+	default:
+		return ""
+	}
+
+	minimum, maximum, sum := math.Inf(1), math.Inf(-1), 0.0
+	count, nans := 0, 0
+	for i := 0; i < val.Len(); i++ {
+		f := toFloat(val.Index(i))
+		if math.IsNaN(f) {
+			nans++
+			continue
+		}
+		minimum, maximum = min(minimum, f), max(maximum, f)
+		sum += f
+		count++
+	}
+
+	var sb strings.Builder
+	if count > 0 {
+		fmt.Fprintf(&sb, ", min = %g, max = %g, mean = %g", minimum, maximum, sum/float64(count))
+	}
+	if kind := val.Type().Elem().Kind(); kind == reflect.Float32 || kind == reflect.Float64 {
+		fmt.Fprintf(&sb, ", NaN = %d", nans)
+	}
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package pretty
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTruncateStrategies(t *testing.T) {
+	ints := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := []struct {
+		name     string
+		strategy TruncateStrategy
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "head and tail",
+			strategy: TruncateHeadTail,
+			input:    ints,
+			expected: "[\n  1,\n  2,\n  ... 6 more elements ...,\n  9,\n  10,\n  // len() = 10\n]",
+		},
+		{
+			name:     "head",
+			strategy: TruncateHead,
+			input:    ints,
+			expected: "[\n  1,\n  2,\n  3,\n  4,\n  ... 6 more elements ...,\n  // len() = 10\n]",
+		},
+		{
+			name:     "tail",
+			strategy: TruncateTail,
+			input:    ints,
+			expected: "[\n  ... 6 more elements ...,\n  7,\n  8,\n  9,\n  10,\n  // len() = 10\n]",
+		},
+		{
+			name:     "sample",
+			strategy: TruncateSample,
+			input:    ints,
+			expected: "[\n  [0]: 1,\n  [3]: 4,\n  [6]: 7,\n  [9]: 10,\n  // len() = 10\n]",
+		},
+		{
+			name:     "summary of ints",
+			strategy: TruncateSummary,
+			input:    ints,
+			expected: "[\n  1,\n  2,\n  ... 6 more elements ...,\n  9,\n  10,\n  // len() = 10, min = 1, max = 10, mean = 5.5\n]",
+		},
+		{
+			name:     "summary of floats",
+			strategy: TruncateSummary,
+			input:    []float64{0.5, math.NaN(), 2, 3, 4.5},
+			expected: "[\n  0.5,\n  NaN,\n  ... 1 more elements ...,\n  3,\n  4.5,\n  // len() = 5, min = 0.5, max = 4.5, mean = 2.5, NaN = 1\n]",
+		},
+		{
+			name:     "summary of strings",
+			strategy: TruncateSummary,
+			input:    []string{"a", "b", "c", "d", "e"},
+			expected: "[\n  \"a\",\n  \"b\",\n  ... 1 more elements ...,\n  \"d\",\n  \"e\",\n  // len() = 5\n]",
+		},
+	}
+
+	printer := New().WithColorMode(ColorNever).WithMaxSliceLength(4)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := printer.WithTruncateStrategy(tt.strategy).Print(tt.input)
+			if result != tt.expected {
+				t.Errorf("Print() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateStrategyTag(t *testing.T) {
+	type logs struct {
+		Lines  []string   `pretty:"truncate=tail"`
+		Nested [][]string `pretty:"truncate=tail"`
+		Other  []string
+	}
+
+	lines := []string{"a", "b", "c", "d"}
+	result := New().WithColorMode(ColorNever).WithMaxSliceLength(2).Print(logs{
+		Lines:  lines,
+		Nested: [][]string{lines},
+		Other:  lines,
+	})
+	expected := `logs{
+  Lines: [
+    ... 2 more elements ...,
+    "c",
+    "d",
+    // len() = 4
+  ],
+  Nested: [
+    [
+      "a",
+      ... 2 more elements ...,
+      "d",
+      // len() = 4
+    ]
+  ],
+  Other: [
+    "a",
+    ... 2 more elements ...,
+    "d",
+    // len() = 4
+  ]
+}`
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}
+
+func TestTruncateStrategyTables(t *testing.T) {
+	rows := []Point{{1, 2}, {3, 4}, {5, 6}}
+	result := New().WithColorMode(ColorNever).WithTableMode(TableAlways).
+		WithMaxSliceLength(2).WithTruncateStrategy(TruncateTail).Print(rows)
+	expected := "[\n  X  Y\n  ... 1 more rows ...\n  3  4\n  5  6\n  // len() = 3\n]"
+	if result != expected {
+		t.Errorf("Print() = %q, want %q", result, expected)
+	}
+}